@@ -2,13 +2,14 @@ package main
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	"mcmocknald-order-kiosk/internal/config"
 	v1 "mcmocknald-order-kiosk/internal/controller/v1"
@@ -47,18 +48,27 @@ import (
 // @tag.name foods
 // @tag.description Food item display endpoints for kiosk
 
+// @tag.name queue
+// @tag.description Queue inspection and reordering endpoints for shift managers
+
 // Application holds all dependencies
 // Following Dependency Injection pattern and MVC architecture
 type Application struct {
-	Config            *config.Config
-	Logger            logger.Logger
-	OrderService      service.OrderService
-	CookService       service.CookService
-	FoodService       service.FoodService
-	V1OrderController *v1.OrderController // API v1 controller
-	V1CookController  *v1.CookController  // API v1 controller
-	V1FoodController  *v1.FoodController  // API v1 controller
-	Router            *gin.Engine
+	Config                 *config.Config
+	Logger                 logger.Logger
+	OrderService           service.OrderService
+	CookService            service.CookService
+	FoodService            service.FoodService
+	QueueService           service.QueueService
+	Autoscaler             service.Autoscaler
+	Reaper                 service.StuckOrderReaper
+	OrderQueue             queue.OrderQueue
+	V1OrderController      *v1.OrderController      // API v1 controller
+	V1CookController       *v1.CookController       // API v1 controller
+	V1FoodController       *v1.FoodController       // API v1 controller
+	V1QueueController      *v1.QueueController      // API v1 controller
+	V1AutoscalerController *v1.AutoscalerController // API v1 controller
+	Router                 *gin.Engine
 }
 
 func main() {
@@ -91,6 +101,36 @@ func main() {
 		log.Fatalf("Failed to seed initial data: %v", err)
 	}
 
+	// Rehydrate the in-process queue with orders persisted by a previous run
+	// (the Postgres queue is the "order" table itself, so nothing is lost on restart)
+	if cfg.IsDatabaseMode() && !cfg.IsPostgresQueue() {
+		if _, err := app.OrderService.RestoreQueue(context.Background()); err != nil {
+			appLogger.Error("Failed to restore order queue: %v", err)
+			log.Fatalf("Failed to restore order queue: %v", err)
+		}
+	}
+
+	// Reload the queue written at the last shutdown in memory mode, re-creating its orders
+	// in the fresh in-memory repository under their original IDs
+	if cfg.IsMemoryMode() {
+		if _, err := app.QueueService.LoadQueue(context.Background(), cfg.QueueSnapshotPath); err != nil {
+			appLogger.Error("Failed to load order queue snapshot: %v", err)
+		}
+	}
+
+	// Start cook workers, creating cook bots up to INITIAL_COOK_BOTS
+	// (cooks created or reinstated through the API later get their worker automatically)
+	if err := app.CookService.StartWorkerPool(context.Background(), cfg.InitialCookBots); err != nil {
+		appLogger.Error("Failed to start worker pool: %v", err)
+		log.Fatalf("Failed to start worker pool: %v", err)
+	}
+
+	// Size the cook pool to the queue backlog (disabled unless AUTOSCALE_MAX_COOKS is set)
+	app.Autoscaler.Start(context.Background())
+
+	// Requeue SERVING orders orphaned by crashed instances (disabled with REAPER_INTERVAL=0)
+	app.Reaper.Start(context.Background())
+
 	// Start HTTP server
 	srv := &http.Server{
 		Addr:    ":" + cfg.ServerPort,
@@ -113,17 +153,37 @@ func main() {
 
 	appLogger.Info("Shutting down server...")
 
-	// Graceful shutdown with 30 second timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	// Graceful shutdown bounded by SHUTDOWN_TIMEOUT
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
-	// Stop worker pool
-	app.CookService.StopWorkerPool()
-
-	// Shutdown HTTP server
+	// Stop intake first so no new orders arrive while draining
 	if err := srv.Shutdown(ctx); err != nil {
 		appLogger.Error("Server forced to shutdown: %v", err)
-		log.Fatal("Server forced to shutdown:", err)
+	}
+
+	// Stop scaling and sweeping before the workers they act on
+	app.Autoscaler.Stop()
+	app.Reaper.Stop()
+
+	// Stop cooks picking up orders, then let SERVING orders finish; unfinished ones go back to PENDING
+	app.CookService.StopWorkerPool()
+	if returned := app.CookService.DrainOrders(ctx); returned > 0 {
+		appLogger.Info("Shutdown deadline reached - %d orders returned to the queue", returned)
+	}
+
+	// The in-process queue dies with the process in memory mode, so write it out
+	if cfg.IsMemoryMode() {
+		if _, err := app.QueueService.PersistQueue(context.Background(), cfg.QueueSnapshotPath); err != nil {
+			appLogger.Error("Failed to persist order queue: %v", err)
+		}
+	}
+
+	// Release queue resources (e.g. the Postgres LISTEN connection)
+	if closer, ok := app.OrderQueue.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			appLogger.Error("Failed to close order queue: %v", err)
+		}
 	}
 
 	appLogger.Info("Server stopped")
@@ -136,6 +196,7 @@ func buildApplication(cfg *config.Config, appLogger logger.Logger) (*Application
 	var userRepo domain.UserRepository
 	var orderRepo domain.OrderRepository
 	var foodRepo domain.FoodRepository
+	var db *sql.DB
 
 	// Initialize repositories based on mode (Dependency Inversion Principle)
 	if cfg.IsMemoryMode() {
@@ -151,7 +212,8 @@ func buildApplication(cfg *config.Config, appLogger logger.Logger) (*Application
 		appLogger.Info("Connecting to database: %s:%s/%s", cfg.DBHost, cfg.DBPort, cfg.DBName)
 
 		// Connect to PostgreSQL
-		db, err := postgres.NewDatabase(cfg.GetDatabaseDSN())
+		var err error
+		db, err = postgres.NewDatabase(cfg.GetDatabaseDSN())
 		if err != nil {
 			return nil, fmt.Errorf("failed to connect to database: %w", err)
 		}
@@ -164,33 +226,135 @@ func buildApplication(cfg *config.Config, appLogger logger.Logger) (*Application
 		// _ = postgres.NewRoleRepository(db)
 	}
 
-	// Initialize priority queue
-	orderQueue := queue.NewPriorityQueue()
+	// Initialize order queue (Dependency Inversion Principle: in-process or shared via PostgreSQL)
+	var orderQueue queue.OrderQueue
+	if cfg.IsPostgresQueue() {
+		appLogger.Info("Initializing PostgreSQL order queue (shared across instances)")
+		pgQueue, err := postgres.NewOrderQueue(db, cfg.GetDatabaseDSN(), cfg.QueuePriorityClasses, cfg.QueueAdmissionLimits(), appLogger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create postgres order queue: %w", err)
+		}
+		// Rank waiting orders by the configured classes (the migration backfill assumed the defaults)
+		reranked, err := pgQueue.Rerank(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("failed to rerank postgres order queue: %w", err)
+		}
+		appLogger.Info("Queued orders re-ranked by the configured priority classes: %d", reranked)
+		orderQueue = pgQueue
+	} else {
+		// In-process priority queue (configured priority classes, scheduling policy picked by name)
+		policy, err := queue.NewSchedulingPolicy(cfg.QueueSchedulingPolicy, cfg.QueuePolicySettings())
+		if err != nil {
+			return nil, fmt.Errorf("failed to create scheduling policy: %w", err)
+		}
+		priorityQueue := queue.NewPriorityQueue(
+			queue.WithPriorityClasses(cfg.QueuePriorityClasses),
+			queue.WithSchedulingPolicy(policy),
+			queue.WithAdmissionLimits(cfg.QueueAdmissionLimits()),
+		)
+		appLogger.Info("Queue priority classes: %v", priorityQueue.Classes())
+		appLogger.Info("Queue scheduling policy: %s", cfg.QueueSchedulingPolicy)
+		if cfg.QueueSchedulingPolicy == queue.PolicyWeighted {
+			appLogger.Info("Queue class weights: %v", cfg.QueueClassWeights)
+		}
+		if cfg.QueueRegularMaxWait > 0 {
+			appLogger.Info("Queue aging enabled: lower-class orders wait at most %v behind higher classes", cfg.QueueRegularMaxWait)
+		}
+		orderQueue = priorityQueue
+	}
+	if cfg.QueueMaxDepth > 0 || len(cfg.QueueMaxClassDepths) > 0 {
+		appLogger.Info("Queue admission limits: total %d, per class %v (0 = unlimited)", cfg.QueueMaxDepth, cfg.QueueMaxClassDepths)
+	}
+
+	// Initialize the order pipeline (ORDER_PIPELINE_STAGES): the first stage takes orders from the queue above,
+	// later stages get queues of their own with the same classes and no admission limits
+	// (an order admitted at intake is never refused halfway through)
+	var pipeline *service.Pipeline
+	if cfg.IsPipelineEnabled() {
+		var err error
+		pipeline, err = service.NewPipeline(cfg.OrderPipelineStages, orderQueue, func() (queue.OrderQueue, error) {
+			policy, err := queue.NewSchedulingPolicy(cfg.QueueSchedulingPolicy, cfg.QueuePolicySettings())
+			if err != nil {
+				return nil, err
+			}
+			return queue.NewPriorityQueue(
+				queue.WithPriorityClasses(cfg.QueuePriorityClasses),
+				queue.WithSchedulingPolicy(policy),
+			), nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create order pipeline: %w", err)
+		}
+		appLogger.Info("Order pipeline stages: %v", cfg.OrderPipelineStages)
+	}
+	intakeStage := "" // Stage the autoscaler staffs (it watches the intake queue)
+	if pipeline != nil {
+		intakeStage = pipeline.First()
+	}
+
+	// Simulated cook failures (COOK_FAILURE_RATE, off by default) exercise the retry and FAILED paths
+	failures := service.FailureConfig{Rate: cfg.CookFailureRate, MaxAttempts: cfg.OrderMaxAttempts}
+	if cfg.CookFailureRate > 0 {
+		appLogger.Info("Failure injection enabled: cook bots fail %.0f%% of attempts, orders FAILED after %d", cfg.CookFailureRate*100, cfg.OrderMaxAttempts)
+	}
 
 	// Initialize services (Dependency Injection)
-	orderService := service.NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, appLogger, cfg.OrderServingDuration)
-	cookService := service.NewCookService(userRepo, orderRepo, orderQueue, appLogger, cfg.OrderServingDuration)
+	cookService := service.NewCookService(userRepo, orderRepo, orderQueue, appLogger, service.CookServiceConfig{
+		ServingDuration: cfg.OrderServingDuration,
+		DefaultCapacity: cfg.CookCapacity,
+		ItemParallelism: cfg.OrderItemParallelism,
+		Pipeline:        pipeline,
+		Failures:        failures,
+	})
+	orderService := service.NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, appLogger, service.OrderServiceConfig{
+		ServingDuration: cfg.OrderServingDuration,
+		SLATargets:      cfg.OrderSLATargets,
+		ItemParallelism: cfg.OrderItemParallelism,
+		Pipeline:        pipeline,
+		Cooks:           cookService, // ETAs and Retry-After count running cooks only
+	})
 	foodService := service.NewFoodService(foodRepo, appLogger)
+	queueService := service.NewQueueService(orderRepo, orderQueue, pipeline, appLogger)
+	autoscaler := service.NewAutoscaler(cookService, orderService, appLogger, service.AutoscalerConfig{
+		MinCooks:       cfg.AutoscaleMinCooks,
+		MaxCooks:       cfg.AutoscaleMaxCooks,
+		BacklogPerCook: cfg.AutoscaleBacklogPerCook,
+		TargetDrain:    cfg.AutoscaleTargetDrain,
+		Interval:       cfg.AutoscaleInterval,
+		UpCooldown:     cfg.AutoscaleUpCooldown,
+		DownCooldown:   cfg.AutoscaleDownCooldown,
+		DownAction:     service.ScaleDownAction(cfg.AutoscaleDownAction),
+		IntakeStage:    intakeStage,
+	})
+	reaper := service.NewStuckOrderReaper(orderService, appLogger, cfg.ReaperInterval, cfg.ReaperStuckMultiple)
 
 	// Initialize controllers (Dependency Injection, MVC pattern)
 	// API v1 controllers
 	v1OrderController := v1.NewOrderController(orderService)
 	v1CookController := v1.NewCookController(cookService)
 	v1FoodController := v1.NewFoodController(foodService)
+	v1QueueController := v1.NewQueueController(queueService)
+	v1AutoscalerController := v1.NewAutoscalerController(autoscaler)
 
 	// Initialize router
-	router := setupRouter(cfg, v1OrderController, v1CookController, v1FoodController)
+	router := setupRouter(cfg, v1OrderController, v1CookController, v1FoodController, v1QueueController, v1AutoscalerController)
 
 	return &Application{
-		Config:            cfg,
-		Logger:            appLogger,
-		OrderService:      orderService,
-		CookService:       cookService,
-		FoodService:       foodService,
-		V1OrderController: v1OrderController,
-		V1CookController:  v1CookController,
-		V1FoodController:  v1FoodController,
-		Router:            router,
+		Config:                 cfg,
+		Logger:                 appLogger,
+		OrderService:           orderService,
+		CookService:            cookService,
+		FoodService:            foodService,
+		QueueService:           queueService,
+		Autoscaler:             autoscaler,
+		Reaper:                 reaper,
+		OrderQueue:             orderQueue,
+		V1OrderController:      v1OrderController,
+		V1CookController:       v1CookController,
+		V1FoodController:       v1FoodController,
+		V1QueueController:      v1QueueController,
+		V1AutoscalerController: v1AutoscalerController,
+		Router:                 router,
 	}, nil
 }
 
@@ -201,6 +365,8 @@ func setupRouter(
 	v1OrderCtrl *v1.OrderController,
 	v1CookCtrl *v1.CookController,
 	v1FoodCtrl *v1.FoodController,
+	v1QueueCtrl *v1.QueueController,
+	v1AutoscalerCtrl *v1.AutoscalerController,
 ) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.Default()
@@ -220,19 +386,24 @@ func setupRouter(
 			// Order routes v1
 			v1Orders := v1Group.Group("/orders")
 			{
-				v1Orders.POST("", v1OrderCtrl.CreateOrder)        // POST /api/v1/orders
-				v1Orders.GET("/:id", v1OrderCtrl.GetOrder)        // GET /api/v1/orders/:id
-				v1Orders.GET("/stats", v1OrderCtrl.GetOrderStats) // GET /api/v1/orders/stats
+				v1Orders.POST("", v1OrderCtrl.CreateOrder)              // POST /api/v1/orders
+				v1Orders.GET("/:id", v1OrderCtrl.GetOrder)              // GET /api/v1/orders/:id
+				v1Orders.GET("/stats", v1OrderCtrl.GetOrderStats)       // GET /api/v1/orders/stats
+				v1Orders.POST("/:id/cancel", v1OrderCtrl.CancelOrder)   // POST /api/v1/orders/:id/cancel
+				v1Orders.POST("/:id/requeue", v1OrderCtrl.RequeueOrder) // POST /api/v1/orders/:id/requeue
 			}
 
 			// Cook routes v1
 			v1Cooks := v1Group.Group("/cooks")
 			{
-				v1Cooks.POST("", v1CookCtrl.CreateCook)                  // POST /api/v1/cooks
-				v1Cooks.GET("", v1CookCtrl.GetAllCooks)                  // GET /api/v1/cooks
-				v1Cooks.DELETE("/:id", v1CookCtrl.RemoveCook)            // DELETE /api/v1/cooks/:id
-				v1Cooks.POST("/:id/reinstate", v1CookCtrl.ReinstateCook) // POST /api/v1/cooks/:id/reinstate
-				v1Cooks.POST("/:id/accept", v1CookCtrl.AcceptOrder)      // POST /api/v1/cooks/:id/accept
+				v1Cooks.POST("", v1CookCtrl.CreateCook)                                 // POST /api/v1/cooks
+				v1Cooks.GET("", v1CookCtrl.GetAllCooks)                                 // GET /api/v1/cooks
+				v1Cooks.DELETE("/:id", v1CookCtrl.RemoveCook)                           // DELETE /api/v1/cooks/:id
+				v1Cooks.POST("/:id/reinstate", v1CookCtrl.ReinstateCook)                // POST /api/v1/cooks/:id/reinstate
+				v1Cooks.POST("/:id/pause", v1CookCtrl.PauseCook)                        // POST /api/v1/cooks/:id/pause
+				v1Cooks.POST("/:id/resume", v1CookCtrl.ResumeCook)                      // POST /api/v1/cooks/:id/resume
+				v1Cooks.POST("/:id/accept", v1CookCtrl.AcceptOrder)                     // POST /api/v1/cooks/:id/accept
+				v1Cooks.POST("/:id/orders/:orderId/complete", v1CookCtrl.CompleteOrder) // POST /api/v1/cooks/:id/orders/:orderId/complete
 			}
 
 			// Food routes v1
@@ -241,6 +412,17 @@ func setupRouter(
 				v1Foods.GET("", v1FoodCtrl.GetAllFoods)     // GET /api/v1/foods?type=Food
 				v1Foods.GET("/:id", v1FoodCtrl.GetFoodByID) // GET /api/v1/foods/:id
 			}
+
+			// Queue admin routes v1
+			v1Queue := v1Group.Group("/queue")
+			{
+				v1Queue.GET("", v1QueueCtrl.GetQueue)               // GET /api/v1/queue
+				v1Queue.POST("/:id/front", v1QueueCtrl.MoveToFront) // POST /api/v1/queue/:id/front
+				v1Queue.PUT("/:id/class", v1QueueCtrl.SetClass)     // PUT /api/v1/queue/:id/class
+			}
+
+			// Autoscaler routes v1
+			v1Group.GET("/autoscaler", v1AutoscalerCtrl.GetStatus) // GET /api/v1/autoscaler
 		}
 	}
 
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/pkg/queue"
+
 	"github.com/joho/godotenv"
 )
 
@@ -17,6 +21,14 @@ const (
 	ModeDatabase Mode = "database"
 )
 
+// QueueBackend represents where the order queue lives
+type QueueBackend string
+
+const (
+	QueueBackendMemory   QueueBackend = "memory"   // In-process priority queue (single instance)
+	QueueBackendPostgres QueueBackend = "postgres" // "order" table with SKIP LOCKED claims (multi-instance)
+)
+
 // Environment represents the application environment
 type Environment string
 
@@ -37,6 +49,8 @@ type Config struct {
 
 	// Server configuration
 	ServerPort string
+	// ShutdownTimeout bounds how long in-flight orders may keep cooking after SIGINT/SIGTERM
+	ShutdownTimeout time.Duration
 
 	// Database configuration
 	DBHost     string
@@ -48,9 +62,57 @@ type Config struct {
 
 	// Order processing configuration
 	OrderServingDuration time.Duration
+	// OrderItemParallelism is how many items of an order a cook prepares at once (0 = all items)
+	OrderItemParallelism int
+	// OrderSLATargets is the time allowed from creation to completion per customer role (no entry = no SLA)
+	OrderSLATargets map[domain.RoleType]time.Duration
+	// OrderPipelineStages is the ordered list of stages with their own queue and cooks (empty = single stage)
+	OrderPipelineStages []domain.PipelineStage
 
 	// Worker configuration
 	InitialCookBots int
+	// CookCapacity is how many orders a cook serves concurrently unless the cook sets its own capacity
+	CookCapacity int
+	// CookFailureRate is the probability that a cook bot fails an order attempt (0 = never; failure injection)
+	CookFailureRate float64
+	// OrderMaxAttempts is how many failed attempts an order gets before it is FAILED
+	OrderMaxAttempts int
+
+	// Stuck-order reaper configuration (ReaperInterval 0 disables the reaper)
+	ReaperInterval time.Duration
+	// ReaperStuckMultiple is how many expected cook times an order may stay SERVING before it is requeued
+	ReaperStuckMultiple int
+
+	// Autoscaler configuration (AutoscaleMaxCooks 0 disables the autoscaler)
+	AutoscaleMinCooks int
+	AutoscaleMaxCooks int
+	// AutoscaleBacklogPerCook is the queued orders per working cook above which a cook is added
+	AutoscaleBacklogPerCook int
+	// AutoscaleTargetDrain is the time within which a backlog the recent throughput clears gets no cook added (0 = backlog only)
+	AutoscaleTargetDrain  time.Duration
+	AutoscaleInterval     time.Duration
+	AutoscaleUpCooldown   time.Duration
+	AutoscaleDownCooldown time.Duration
+	// AutoscaleDownAction is how idle cooks are taken off the line (pause or remove)
+	AutoscaleDownAction string
+
+	// Queue configuration
+	// QueueBackend selects the in-process queue or the shared Postgres queue (database mode only)
+	QueueBackend QueueBackend
+	// QueueRegularMaxWait bounds how long a lower-class order can be starved by higher classes (0 disables aging)
+	QueueRegularMaxWait time.Duration
+	// QueuePriorityClasses is the ordered set of priority classes (highest first) and their role mapping
+	QueuePriorityClasses []queue.PriorityClass
+	// QueueSchedulingPolicy is the name of the policy choosing between classes (strict, weighted, ...)
+	QueueSchedulingPolicy string
+	// QueueClassWeights is the weighted round-robin share per class (weighted policy only)
+	QueueClassWeights []int
+	// QueueMaxDepth caps the total number of waiting orders; new orders are refused beyond it (0 = unlimited)
+	QueueMaxDepth int
+	// QueueMaxClassDepths caps waiting orders per priority class, highest first (empty = no class limits)
+	QueueMaxClassDepths []int
+	// QueueSnapshotPath is where the waiting orders are written at shutdown (memory mode only)
+	QueueSnapshotPath string
 
 	// Logging configuration
 	LogDirectory string
@@ -63,19 +125,74 @@ func Load() (*Config, error) {
 	_ = godotenv.Load()
 
 	config := &Config{
-		Mode:                 Mode(getEnv("MODE", "memory")),
-		Environment:          Environment(getEnv("ENV", "development")),
-		ServerPort:           getEnv("SERVER_PORT", "8080"),
-		DBHost:               getEnv("DB_HOST", "localhost"),
-		DBPort:               getEnv("DB_PORT", "7001"),
-		DBUser:               getEnv("DB_USER", "postgres"),
-		DBPassword:           getEnv("DB_PASSWORD", "postgres"),
-		DBName:               getEnv("DB_NAME", "mcmocknald"),
-		DBSSLMode:            getEnv("DB_SSL_MODE", "disable"),
-		OrderServingDuration: getDurationEnv("ORDER_SERVING_DURATION", 10*time.Second),
-		InitialCookBots:      getIntEnv("INITIAL_COOK_BOTS", 1),
-		LogDirectory:         getEnv("LOG_DIRECTORY", "./logs"),
+		Mode:                    Mode(getEnv("MODE", "memory")),
+		Environment:             Environment(getEnv("ENV", "development")),
+		ServerPort:              getEnv("SERVER_PORT", "8080"),
+		ShutdownTimeout:         getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
+		DBHost:                  getEnv("DB_HOST", "localhost"),
+		DBPort:                  getEnv("DB_PORT", "7001"),
+		DBUser:                  getEnv("DB_USER", "postgres"),
+		DBPassword:              getEnv("DB_PASSWORD", "postgres"),
+		DBName:                  getEnv("DB_NAME", "mcmocknald"),
+		DBSSLMode:               getEnv("DB_SSL_MODE", "disable"),
+		OrderServingDuration:    getDurationEnv("ORDER_SERVING_DURATION", 10*time.Second),
+		OrderItemParallelism:    getIntEnv("ORDER_ITEM_PARALLELISM", 0),
+		InitialCookBots:         getIntEnv("INITIAL_COOK_BOTS", 1),
+		CookCapacity:            getIntEnv("COOK_CAPACITY", 1),
+		CookFailureRate:         getFloatEnv("COOK_FAILURE_RATE", 0),
+		OrderMaxAttempts:        getIntEnv("ORDER_MAX_ATTEMPTS", 3),
+		ReaperInterval:          getDurationEnv("REAPER_INTERVAL", 30*time.Second),
+		ReaperStuckMultiple:     getIntEnv("REAPER_STUCK_MULTIPLE", 3),
+		AutoscaleMinCooks:       getIntEnv("AUTOSCALE_MIN_COOKS", 1),
+		AutoscaleMaxCooks:       getIntEnv("AUTOSCALE_MAX_COOKS", 0),
+		AutoscaleBacklogPerCook: getIntEnv("AUTOSCALE_BACKLOG_PER_COOK", 5),
+		AutoscaleTargetDrain:    getDurationEnv("AUTOSCALE_TARGET_DRAIN", time.Minute),
+		AutoscaleInterval:       getDurationEnv("AUTOSCALE_INTERVAL", 5*time.Second),
+		AutoscaleUpCooldown:     getDurationEnv("AUTOSCALE_UP_COOLDOWN", 30*time.Second),
+		AutoscaleDownCooldown:   getDurationEnv("AUTOSCALE_DOWN_COOLDOWN", 2*time.Minute),
+		AutoscaleDownAction:     getEnv("AUTOSCALE_DOWN_ACTION", "pause"),
+		QueueBackend:            QueueBackend(getEnv("QUEUE_BACKEND", "memory")),
+		QueueRegularMaxWait:     getDurationEnv("QUEUE_REGULAR_MAX_WAIT", 0),
+		QueueSchedulingPolicy:   getEnv("QUEUE_SCHEDULING_POLICY", queue.PolicyStrict),
+		QueueMaxDepth:           getIntEnv("QUEUE_MAX_DEPTH", 0),
+		QueueSnapshotPath:       getEnv("QUEUE_SNAPSHOT_PATH", "./data/queue_snapshot.json"),
+		LogDirectory:            getEnv("LOG_DIRECTORY", "./logs"),
+	}
+
+	// Parse SLA targets per customer role
+	slaTargets, err := parseSLATargets(getEnv("ORDER_SLA_TARGETS", DefaultOrderSLATargets))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: ORDER_SLA_TARGETS: %w", err)
+	}
+	config.OrderSLATargets = slaTargets
+
+	// Parse the order pipeline (empty spec keeps a single stage)
+	stages, err := parsePipelineStages(getEnv("ORDER_PIPELINE_STAGES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: ORDER_PIPELINE_STAGES: %w", err)
+	}
+	config.OrderPipelineStages = stages
+
+	// Parse priority classes (empty spec keeps the default VIP/Regular tiers)
+	classes, err := queue.ParsePriorityClasses(getEnv("QUEUE_PRIORITY_CLASSES", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: QUEUE_PRIORITY_CLASSES: %w", err)
+	}
+	config.QueuePriorityClasses = classes
+
+	// Parse weighted round-robin shares (only used by the weighted policy)
+	weights, err := queue.ParseClassWeights(getEnv("QUEUE_CLASS_WEIGHTS", queue.DefaultClassWeights))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: QUEUE_CLASS_WEIGHTS: %w", err)
+	}
+	config.QueueClassWeights = weights
+
+	// Parse per-class admission limits (empty spec leaves classes unlimited)
+	classDepths, err := queue.ParseClassDepths(getEnv("QUEUE_MAX_CLASS_DEPTHS", ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: QUEUE_MAX_CLASS_DEPTHS: %w", err)
 	}
+	config.QueueMaxClassDepths = classDepths
 
 	// Validate configuration
 	if err := config.Validate(); err != nil {
@@ -129,10 +246,112 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("ORDER_SERVING_DURATION must be positive")
 	}
 
+	if c.ShutdownTimeout <= 0 {
+		return fmt.Errorf("SHUTDOWN_TIMEOUT must be positive")
+	}
+
+	if c.OrderItemParallelism < 0 {
+		return fmt.Errorf("ORDER_ITEM_PARALLELISM must be non-negative")
+	}
+
+	for role, target := range c.OrderSLATargets {
+		if target <= 0 {
+			return fmt.Errorf("ORDER_SLA_TARGETS: target for %s must be positive", role)
+		}
+	}
+
 	if c.InitialCookBots < 0 {
 		return fmt.Errorf("INITIAL_COOK_BOTS must be non-negative")
 	}
 
+	if c.CookCapacity < 1 {
+		return fmt.Errorf("COOK_CAPACITY must be at least 1")
+	}
+
+	if c.CookFailureRate < 0 || c.CookFailureRate > 1 {
+		return fmt.Errorf("COOK_FAILURE_RATE must be between 0 and 1")
+	}
+
+	if c.OrderMaxAttempts < 1 {
+		return fmt.Errorf("ORDER_MAX_ATTEMPTS must be at least 1")
+	}
+
+	if c.ReaperInterval < 0 {
+		return fmt.Errorf("REAPER_INTERVAL must be non-negative")
+	}
+
+	if c.ReaperStuckMultiple < 2 {
+		return fmt.Errorf("REAPER_STUCK_MULTIPLE must be at least 2")
+	}
+
+	if c.IsAutoscaleEnabled() {
+		if c.AutoscaleMinCooks < 0 || c.AutoscaleMinCooks > c.AutoscaleMaxCooks {
+			return fmt.Errorf("AUTOSCALE_MIN_COOKS must be between 0 and AUTOSCALE_MAX_COOKS")
+		}
+		if c.AutoscaleBacklogPerCook < 1 {
+			return fmt.Errorf("AUTOSCALE_BACKLOG_PER_COOK must be at least 1")
+		}
+		if c.AutoscaleInterval <= 0 {
+			return fmt.Errorf("AUTOSCALE_INTERVAL must be positive")
+		}
+		if c.AutoscaleTargetDrain < 0 {
+			return fmt.Errorf("AUTOSCALE_TARGET_DRAIN must be non-negative")
+		}
+		if c.AutoscaleUpCooldown < 0 || c.AutoscaleDownCooldown < 0 {
+			return fmt.Errorf("AUTOSCALE_UP_COOLDOWN and AUTOSCALE_DOWN_COOLDOWN must be non-negative")
+		}
+		if c.AutoscaleDownAction != "pause" && c.AutoscaleDownAction != "remove" {
+			return fmt.Errorf("invalid AUTOSCALE_DOWN_ACTION: %s (must be 'pause' or 'remove')", c.AutoscaleDownAction)
+		}
+	} else if c.AutoscaleMaxCooks < 0 {
+		return fmt.Errorf("AUTOSCALE_MAX_COOKS must be non-negative")
+	}
+
+	if c.QueueBackend != QueueBackendMemory && c.QueueBackend != QueueBackendPostgres {
+		return fmt.Errorf("invalid QUEUE_BACKEND: %s (must be 'memory' or 'postgres')", c.QueueBackend)
+	}
+
+	if c.QueueBackend == QueueBackendPostgres {
+		if c.Mode != ModeDatabase {
+			return fmt.Errorf("QUEUE_BACKEND=postgres requires database mode")
+		}
+		if c.QueueRegularMaxWait > 0 {
+			return fmt.Errorf("QUEUE_REGULAR_MAX_WAIT is not supported with QUEUE_BACKEND=postgres")
+		}
+		if c.QueueSchedulingPolicy != queue.PolicyStrict {
+			return fmt.Errorf("QUEUE_SCHEDULING_POLICY=%s is not supported with QUEUE_BACKEND=postgres", c.QueueSchedulingPolicy)
+		}
+	}
+
+	if len(c.OrderPipelineStages) > 0 && c.QueueBackend == QueueBackendPostgres {
+		return fmt.Errorf("ORDER_PIPELINE_STAGES is not supported with QUEUE_BACKEND=postgres")
+	}
+
+	if c.QueueRegularMaxWait < 0 {
+		return fmt.Errorf("QUEUE_REGULAR_MAX_WAIT must be non-negative")
+	}
+
+	if len(c.QueuePriorityClasses) == 0 {
+		return fmt.Errorf("QUEUE_PRIORITY_CLASSES must define at least one class")
+	}
+
+	if c.QueueMaxDepth < 0 {
+		return fmt.Errorf("QUEUE_MAX_DEPTH must be non-negative")
+	}
+
+	if err := c.QueueAdmissionLimits().ValidateClassCount(len(c.QueuePriorityClasses)); err != nil {
+		return fmt.Errorf("invalid QUEUE_MAX_CLASS_DEPTHS: %w", err)
+	}
+
+	if _, err := queue.NewSchedulingPolicy(c.QueueSchedulingPolicy, c.QueuePolicySettings()); err != nil {
+		return fmt.Errorf("invalid QUEUE_SCHEDULING_POLICY: %w", err)
+	}
+
+	if c.QueueSchedulingPolicy == queue.PolicyWeighted && len(c.QueueClassWeights) != len(c.QueuePriorityClasses) {
+		return fmt.Errorf("QUEUE_CLASS_WEIGHTS must have one weight per priority class (%d weights, %d classes)",
+			len(c.QueueClassWeights), len(c.QueuePriorityClasses))
+	}
+
 	return nil
 }
 
@@ -145,6 +364,30 @@ func (c *Config) GetDatabaseDSN() string {
 	)
 }
 
+// QueuePolicySettings returns the settings used to build the configured scheduling policy
+// Time Complexity: O(1)
+func (c *Config) QueuePolicySettings() queue.PolicySettings {
+	return queue.PolicySettings{
+		MaxWait: c.QueueRegularMaxWait,
+		Weights: c.QueueClassWeights,
+	}
+}
+
+// QueueAdmissionLimits returns the queue depth limits applied to new orders
+// Time Complexity: O(1)
+func (c *Config) QueueAdmissionLimits() queue.AdmissionLimits {
+	return queue.AdmissionLimits{
+		MaxDepth:       c.QueueMaxDepth,
+		MaxClassDepths: c.QueueMaxClassDepths,
+	}
+}
+
+// IsPipelineEnabled checks if orders pass through several stages
+// Time Complexity: O(1)
+func (c *Config) IsPipelineEnabled() bool {
+	return len(c.OrderPipelineStages) > 0
+}
+
 // IsMemoryMode checks if the application is running in memory mode
 // Time Complexity: O(1)
 func (c *Config) IsMemoryMode() bool {
@@ -157,6 +400,18 @@ func (c *Config) IsDatabaseMode() bool {
 	return c.Mode == ModeDatabase
 }
 
+// IsPostgresQueue checks if the order queue is shared through PostgreSQL
+// Time Complexity: O(1)
+func (c *Config) IsPostgresQueue() bool {
+	return c.QueueBackend == QueueBackendPostgres
+}
+
+// IsAutoscaleEnabled checks if the autoscaler sizes the cook pool (AUTOSCALE_MAX_COOKS set)
+// Time Complexity: O(1)
+func (c *Config) IsAutoscaleEnabled() bool {
+	return c.AutoscaleMaxCooks > 0
+}
+
 // IsProduction checks if the application is running in production environment
 // Time Complexity: O(1)
 func (c *Config) IsProduction() bool {
@@ -169,6 +424,81 @@ func (c *Config) IsSwaggerEnabled() bool {
 	return !c.IsProduction()
 }
 
+// DefaultOrderSLATargets is the default SLA per customer role: VIP 3 minutes, Regular 8 minutes
+const DefaultOrderSLATargets = "VIP Customer=3m;Regular Customer=8m"
+
+// parseSLATargets parses per-role SLA targets
+// Format: "Role A=3m;Role B=8m" - entries separated by ';'; "none" disables SLAs entirely
+// Time Complexity: O(n) where n is the length of the specification
+func parseSLATargets(spec string) (map[domain.RoleType]time.Duration, error) {
+	targets := make(map[domain.RoleType]time.Duration)
+	if strings.EqualFold(strings.TrimSpace(spec), "none") {
+		return targets, nil
+	}
+
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		role, value, found := strings.Cut(part, "=")
+		role = strings.TrimSpace(role)
+		if !found || role == "" {
+			return nil, fmt.Errorf("invalid entry %q (expected Role=duration)", part)
+		}
+
+		target, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for %s: %w", role, err)
+		}
+		targets[domain.RoleType(role)] = target
+	}
+
+	return targets, nil
+}
+
+// parsePipelineStages parses the order pipeline
+// Format: "prep=30s,cook,pack=15s" - stages in order, each with an optional fixed duration
+// (stages without one take the order's item prep time)
+// Time Complexity: O(n) where n is the length of the specification
+func parsePipelineStages(spec string) ([]domain.PipelineStage, error) {
+	var stages []domain.PipelineStage
+	seen := make(map[string]bool)
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, value, hasDuration := strings.Cut(part, "=")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("invalid entry %q (expected stage or stage=duration)", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate stage %s", name)
+		}
+		seen[name] = true
+
+		stage := domain.PipelineStage{Name: name}
+		if hasDuration {
+			duration, err := time.ParseDuration(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid duration for %s: %w", name, err)
+			}
+			if duration <= 0 {
+				return nil, fmt.Errorf("duration for %s must be positive", name)
+			}
+			stage.Duration = duration
+		}
+		stages = append(stages, stage)
+	}
+
+	return stages, nil
+}
+
 // Helper functions for environment variable parsing
 
 func getEnv(key, defaultValue string) string {
@@ -193,6 +523,20 @@ func getIntEnv(key string, defaultValue int) int {
 	return intValue
 }
 
+func getFloatEnv(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	floatValue, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return defaultValue
+	}
+
+	return floatValue
+}
+
 func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
 	value := os.Getenv(key)
 	if value == "" {
@@ -0,0 +1,99 @@
+package v1
+
+import (
+	"net/http"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AutoscalerController handles autoscaler HTTP requests (API v1)
+// Following MVC pattern: Controller layer for HTTP handling
+// Following Single Responsibility Principle: only handles HTTP layer for autoscaler visibility
+type AutoscalerController struct {
+	autoscaler service.Autoscaler
+}
+
+// NewAutoscalerController creates a new autoscaler controller
+func NewAutoscalerController(autoscaler service.Autoscaler) *AutoscalerController {
+	return &AutoscalerController{
+		autoscaler: autoscaler,
+	}
+}
+
+// AutoscalerResponse reports the autoscaler bounds, last observation and recent decisions
+type AutoscalerResponse struct {
+	Enabled             bool                      `json:"enabled"`
+	MinCooks            int                       `json:"min_cooks"`
+	MaxCooks            int                       `json:"max_cooks"`
+	BacklogPerCook      int                       `json:"backlog_per_cook"`
+	TargetDrainSeconds  float64                   `json:"target_drain_seconds"`
+	IntervalSeconds     float64                   `json:"interval_seconds"`
+	UpCooldownSeconds   float64                   `json:"up_cooldown_seconds"`
+	DownCooldownSeconds float64                   `json:"down_cooldown_seconds"`
+	DownAction          service.ScaleDownAction   `json:"down_action"`
+	CheckedAt           *time.Time                `json:"checked_at,omitempty"` // Omitted until the first check
+	QueueSize           int                       `json:"queue_size"`
+	WorkingCooks        int                       `json:"working_cooks"`
+	Throughput          float64                   `json:"throughput_per_minute"` // Orders completed per minute over the last interval
+	Decisions           []ScalingDecisionResponse `json:"decisions"`             // Most recent first
+}
+
+// ScalingDecisionResponse is one change the autoscaler made to the cook pool
+type ScalingDecisionResponse struct {
+	At           time.Time             `json:"at"`
+	Action       service.ScalingAction `json:"action"`
+	CookID       int                   `json:"cook_id"`
+	CookName     string                `json:"cook_name"`
+	Reason       string                `json:"reason"`
+	QueueSize    int                   `json:"queue_size"`
+	WorkingCooks int                   `json:"working_cooks"` // Before the decision
+	Throughput   float64               `json:"throughput_per_minute"`
+}
+
+// GetStatus handles GET /api/v1/autoscaler
+// @Summary Get autoscaler status (v1)
+// @Description Autoscaler bounds, what the last check observed and the recent scaling decisions
+// @Tags cooks
+// @Produce json
+// @Success 200 {object} AutoscalerResponse
+// @Router /api/v1/autoscaler [get]
+func (ctrl *AutoscalerController) GetStatus(c *gin.Context) {
+	status := ctrl.autoscaler.Status()
+
+	response := AutoscalerResponse{
+		Enabled:             status.Enabled,
+		MinCooks:            status.Config.MinCooks,
+		MaxCooks:            status.Config.MaxCooks,
+		BacklogPerCook:      status.Config.BacklogPerCook,
+		TargetDrainSeconds:  status.Config.TargetDrain.Seconds(),
+		IntervalSeconds:     status.Config.Interval.Seconds(),
+		UpCooldownSeconds:   status.Config.UpCooldown.Seconds(),
+		DownCooldownSeconds: status.Config.DownCooldown.Seconds(),
+		DownAction:          status.Config.DownAction,
+		QueueSize:           status.QueueSize,
+		WorkingCooks:        status.WorkingCooks,
+		Throughput:          status.Throughput,
+		Decisions:           make([]ScalingDecisionResponse, len(status.Decisions)),
+	}
+	if !status.CheckedAt.IsZero() {
+		response.CheckedAt = &status.CheckedAt
+	}
+
+	for i, decision := range status.Decisions {
+		response.Decisions[i] = ScalingDecisionResponse{
+			At:           decision.At,
+			Action:       decision.Action,
+			CookID:       decision.CookID,
+			CookName:     decision.CookName,
+			Reason:       decision.Reason,
+			QueueSize:    decision.QueueSize,
+			WorkingCooks: decision.WorkingCooks,
+			Throughput:   decision.Throughput,
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -1,9 +1,11 @@
 package v1
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
+	"mcmocknald-order-kiosk/internal/domain"
 	"mcmocknald-order-kiosk/internal/service"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +27,13 @@ func NewCookController(cookService service.CookService) *CookController {
 
 // CreateCookRequest represents the request to create a new cook bot
 type CreateCookRequest struct {
-	Name string `json:"name" binding:"required"`
+	Name         string            `json:"name" binding:"required"`
+	Capacity     int               `json:"capacity"`     // Concurrent orders (omit or 0 = COOK_CAPACITY)
+	Capabilities []domain.FoodType `json:"capabilities"` // Food types the cook can prepare (omit = every type)
+	// CompletionMode is timer (bot, default) or manual (staff complete orders through the complete endpoint)
+	CompletionMode domain.CompletionMode `json:"completion_mode"`
+	// Stage is the pipeline stage the cook works (omit = first stage; must be in ORDER_PIPELINE_STAGES)
+	Stage string `json:"stage"`
 }
 
 // CreateCook handles POST /api/v1/cooks
@@ -46,8 +54,13 @@ func (ctrl *CookController) CreateCook(c *gin.Context) {
 		return
 	}
 
-	cook, err := ctrl.cookService.CreateCook(c.Request.Context(), req.Name)
+	cook, err := ctrl.cookService.CreateCook(c.Request.Context(), req.Name, req.Capacity, req.Capabilities, req.CompletionMode, req.Stage)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCookCapacity) || errors.Is(err, service.ErrInvalidCookCapability) ||
+			errors.Is(err, service.ErrInvalidCompletionMode) || errors.Is(err, service.ErrUnknownStage) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -105,14 +118,75 @@ func (ctrl *CookController) ReinstateCook(c *gin.Context) {
 	c.JSON(http.StatusOK, SuccessResponse{Message: "Cook reinstated successfully"})
 }
 
+// PauseCook handles POST /api/v1/cooks/:id/pause
+// @Summary Pause a cook bot (v1)
+// @Description Stop a cook taking new orders; orders it is already serving are finished
+// @Tags cooks
+// @Produce json
+// @Param id path int true "Cook ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/cooks/{id}/pause [post]
+func (ctrl *CookController) PauseCook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid cook id"})
+		return
+	}
+
+	if err := ctrl.cookService.PauseCook(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrCookPaused) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Cook paused successfully"})
+}
+
+// ResumeCook handles POST /api/v1/cooks/:id/resume
+// @Summary Resume a cook bot (v1)
+// @Description Let a paused cook take orders again
+// @Tags cooks
+// @Produce json
+// @Param id path int true "Cook ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/cooks/{id}/resume [post]
+func (ctrl *CookController) ResumeCook(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid cook id"})
+		return
+	}
+
+	if err := ctrl.cookService.ResumeCook(c.Request.Context(), id); err != nil {
+		if errors.Is(err, service.ErrCookNotPaused) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Cook resumed successfully"})
+}
+
 // AcceptOrder handles POST /api/v1/cooks/:id/accept
 // @Summary Accept an order (v1)
-// @Description Cook accepts the next order from the queue
+// @Description Cook accepts the next order it can prepare from the queue (only while it has a free capacity slot and is not paused)
 // @Tags cooks
 // @Produce json
 // @Param id path int true "Cook ID"
 // @Success 200 {object} domain.Order
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/cooks/{id}/accept [post]
 func (ctrl *CookController) AcceptOrder(c *gin.Context) {
@@ -124,6 +198,10 @@ func (ctrl *CookController) AcceptOrder(c *gin.Context) {
 
 	order, err := ctrl.cookService.AcceptOrder(c.Request.Context(), id)
 	if err != nil {
+		if errors.Is(err, service.ErrCookAtCapacity) || errors.Is(err, service.ErrCookPaused) {
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -131,9 +209,53 @@ func (ctrl *CookController) AcceptOrder(c *gin.Context) {
 	c.JSON(http.StatusOK, order)
 }
 
+// CompleteOrder handles POST /api/v1/cooks/:id/orders/:orderId/complete
+// @Summary Complete an order (v1)
+// @Description A manual cook reports an order it is serving as done (timer cooks complete orders on their own)
+// @Tags cooks
+// @Produce json
+// @Param id path int true "Cook ID"
+// @Param orderId path int true "Order ID"
+// @Success 200 {object} SuccessResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/cooks/{id}/orders/{orderId}/complete [post]
+func (ctrl *CookController) CompleteOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid cook id"})
+		return
+	}
+
+	orderID, err := strconv.Atoi(c.Param("orderId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid order id"})
+		return
+	}
+
+	if err := ctrl.cookService.CompleteOrder(c.Request.Context(), id, orderID); err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrOrderNotAssignedToCook):
+			c.JSON(http.StatusForbidden, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrCookNotManual) || errors.Is(err, service.ErrOrderNotServing):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, SuccessResponse{Message: "Order completed successfully"})
+}
+
 // GetAllCooks handles GET /api/v1/cooks
 // @Summary Get all cook bots (v1)
-// @Description Get all cook bots (optionally including deleted ones)
+// @Description Get all cook bots with their capacity, current load and worker state (optionally including deleted ones)
 // @Tags cooks
 // @Produce json
 // @Param include_deleted query bool false "Include deleted cooks"
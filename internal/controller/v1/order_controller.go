@@ -1,6 +1,8 @@
 package v1
 
 import (
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
@@ -38,6 +40,8 @@ type CreateOrderRequest struct {
 // @Param request body CreateOrderRequest true "Order creation request"
 // @Success 201 {object} domain.Order
 // @Failure 400 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse "No cook can prepare every item of the order"
+// @Failure 429 {object} ErrorResponse "Queue full; Retry-After header gives seconds to wait"
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/orders [post]
 func (ctrl *OrderController) CreateOrder(c *gin.Context) {
@@ -49,6 +53,21 @@ func (ctrl *OrderController) CreateOrder(c *gin.Context) {
 
 	order, err := ctrl.orderService.CreateOrder(c.Request.Context(), req.CustomerID, req.FoodIDs)
 	if err != nil {
+		var full *service.QueueFullError
+		if errors.As(err, &full) {
+			// Retry-After is whole seconds, rounded up so clients never retry too early
+			seconds := int(math.Ceil(full.RetryAfter.Seconds()))
+			if seconds < 1 {
+				seconds = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(seconds))
+			c.JSON(http.StatusTooManyRequests, ErrorResponse{Error: err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrNoCapableCook) {
+			c.JSON(http.StatusUnprocessableEntity, ErrorResponse{Error: err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
@@ -82,33 +101,109 @@ func (ctrl *OrderController) GetOrder(c *gin.Context) {
 	c.JSON(http.StatusOK, order)
 }
 
+// CancelOrder handles POST /api/v1/orders/:id/cancel
+// @Summary Cancel a pending order (v1)
+// @Description Cancel an order and remove it from the queue. Rejected once a cook has taken the order.
+// @Tags orders
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} domain.Order
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/orders/{id}/cancel [post]
+func (ctrl *OrderController) CancelOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid order id"})
+		return
+	}
+
+	order, err := ctrl.orderService.CancelOrder(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrOrderNotCancellable):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// RequeueOrder handles POST /api/v1/orders/:id/requeue
+// @Summary Requeue a failed order (v1)
+// @Description Admin: put a FAILED order back at the end of the queue with its attempts reset
+// @Tags queue
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} domain.Order
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/orders/{id}/requeue [post]
+func (ctrl *OrderController) RequeueOrder(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid order id"})
+		return
+	}
+
+	order, err := ctrl.orderService.RequeueOrder(c.Request.Context(), id)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrderNotFound):
+			c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+		case errors.Is(err, service.ErrOrderNotFailed):
+			c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
 // GetOrderStats handles GET /api/v1/orders/stats
 // @Summary Get order statistics (v1)
-// @Description Get completed and incomplete order counts
+// @Description Get completed, incomplete and failed order counts, and orders completed after their SLA deadline
 // @Tags orders
 // @Produce json
 // @Success 200 {object} OrderStatsResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/v1/orders/stats [get]
 func (ctrl *OrderController) GetOrderStats(c *gin.Context) {
-	completed, incomplete, err := ctrl.orderService.GetOrderStats(c.Request.Context())
+	stats, err := ctrl.orderService.GetOrderStats(c.Request.Context())
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, OrderStatsResponse{
-		Completed:  completed,
-		Incomplete: incomplete,
-		QueueSize:  ctrl.orderService.GetQueueSize(),
+		Completed:   stats.Completed,
+		Incomplete:  stats.Incomplete,
+		Failed:      stats.Failed,
+		SLABreached: stats.SLABreached,
+		Recovered:   stats.Recovered,
+		QueueSize:   ctrl.orderService.GetQueueSize(),
 	})
 }
 
 // OrderStatsResponse represents order statistics
 type OrderStatsResponse struct {
-	Completed  int `json:"completed"`
-	Incomplete int `json:"incomplete"`
-	QueueSize  int `json:"queue_size"`
+	Completed   int `json:"completed"`
+	Incomplete  int `json:"incomplete"`
+	Failed      int `json:"failed"` // Orders out of attempts, waiting to be requeued
+	SLABreached int `json:"sla_breached"`
+	Recovered   int `json:"recovered"` // Stuck SERVING orders requeued by this instance since start
+	QueueSize   int `json:"queue_size"`
 }
 
 // ErrorResponse represents an error response
@@ -0,0 +1,170 @@
+package v1
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// QueueController handles queue administration HTTP requests (API v1)
+// Following MVC pattern: Controller layer for HTTP handling
+// Following Single Responsibility Principle: only handles HTTP layer for queue inspection and reordering
+type QueueController struct {
+	queueService service.QueueService
+}
+
+// NewQueueController creates a new queue controller
+func NewQueueController(queueService service.QueueService) *QueueController {
+	return &QueueController{
+		queueService: queueService,
+	}
+}
+
+// SetClassRequest represents the request to move an order to another priority class
+type SetClassRequest struct {
+	Class string `json:"class" binding:"required"`
+}
+
+// QueueResponse lists the waiting orders per priority class
+type QueueResponse struct {
+	Size    int                  `json:"size"`
+	Classes []QueueClassResponse `json:"classes"`
+}
+
+// QueueClassResponse lists the waiting orders of one priority class, front first
+type QueueClassResponse struct {
+	Stage  string                `json:"stage,omitempty"` // Pipeline stage whose queue the class belongs to
+	Name   string                `json:"name"`
+	Size   int                   `json:"size"`
+	Orders []QueuedOrderResponse `json:"orders"`
+}
+
+// QueuedOrderResponse is a waiting order with its place in its class and wait time
+type QueuedOrderResponse struct {
+	OrderID      int             `json:"order_id"`
+	Position     int             `json:"position"` // 1-based place within its class
+	CustomerName string          `json:"customer_name,omitempty"`
+	CustomerRole domain.RoleType `json:"customer_role,omitempty"`
+	CreatedAt    time.Time       `json:"created_at"`
+	EnqueuedAt   time.Time       `json:"enqueued_at"`
+	WaitSeconds  int             `json:"wait_seconds"` // Whole seconds since the order was placed
+}
+
+// GetQueue handles GET /api/v1/queue
+// @Summary List queued orders (v1)
+// @Description List waiting orders per priority class (per pipeline stage), in service order, with wait times
+// @Tags queue
+// @Produce json
+// @Success 200 {object} QueueResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/queue [get]
+func (ctrl *QueueController) GetQueue(c *gin.Context) {
+	ctrl.respondWithQueue(c)
+}
+
+// MoveToFront handles POST /api/v1/queue/:id/front
+// @Summary Expedite a queued order (v1)
+// @Description Move a waiting order to the front of its priority class
+// @Tags queue
+// @Produce json
+// @Param id path int true "Order ID"
+// @Success 200 {object} QueueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/queue/{id}/front [post]
+func (ctrl *QueueController) MoveToFront(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid order id"})
+		return
+	}
+
+	if err := ctrl.queueService.MoveToFront(c.Request.Context(), id); err != nil {
+		respondWithQueueError(c, err)
+		return
+	}
+
+	ctrl.respondWithQueue(c)
+}
+
+// SetClass handles PUT /api/v1/queue/:id/class
+// @Summary Change the priority class of a queued order (v1)
+// @Description Move a waiting order to the back of another priority class
+// @Tags queue
+// @Accept json
+// @Produce json
+// @Param id path int true "Order ID"
+// @Param request body SetClassRequest true "Target priority class"
+// @Success 200 {object} QueueResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/v1/queue/{id}/class [put]
+func (ctrl *QueueController) SetClass(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid order id"})
+		return
+	}
+
+	var req SetClassRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	if err := ctrl.queueService.SetClass(c.Request.Context(), id, req.Class); err != nil {
+		respondWithQueueError(c, err)
+		return
+	}
+
+	ctrl.respondWithQueue(c)
+}
+
+// respondWithQueue writes the current queue contents
+func (ctrl *QueueController) respondWithQueue(c *gin.Context) {
+	classes, err := ctrl.queueService.GetQueue(c.Request.Context())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	response := QueueResponse{Classes: make([]QueueClassResponse, len(classes))}
+	for i, class := range classes {
+		orders := make([]QueuedOrderResponse, len(class.Orders))
+		for j, queued := range class.Orders {
+			orders[j] = QueuedOrderResponse{
+				OrderID:      queued.Order.ID,
+				Position:     j + 1,
+				CustomerName: queued.Order.CustomerName,
+				CustomerRole: queued.Order.CustomerRole,
+				CreatedAt:    queued.Order.CreatedAt,
+				EnqueuedAt:   queued.EnqueuedAt,
+				WaitSeconds:  int(queued.WaitTime.Seconds()),
+			}
+		}
+		response.Classes[i] = QueueClassResponse{Stage: class.Stage, Name: class.Name, Size: len(orders), Orders: orders}
+		response.Size += len(orders)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// respondWithQueueError maps queue service errors to HTTP status codes
+func respondWithQueueError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrOrderNotQueued):
+		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrUnknownPriorityClass):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: err.Error()})
+	}
+}
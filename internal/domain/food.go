@@ -17,11 +17,21 @@ type Food struct {
 	ID         int       `json:"id" db:"id"`
 	Name       string    `json:"name" db:"name" binding:"required"`
 	Type       FoodType  `json:"type" db:"type" binding:"required"`
+	PrepTimeMs int64     `json:"prep_time_ms,omitempty" db:"prep_time_ms"` // Time to prepare one item (0 = ORDER_SERVING_DURATION)
 	CreatedAt  time.Time `json:"created_at" db:"created_at"`
 	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
 	DeletedAt  *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
 }
 
+// PrepTime returns how long one item takes to prepare, or defaultPrepTime when none was set
+// Time Complexity: O(1)
+func (f *Food) PrepTime(defaultPrepTime time.Duration) time.Duration {
+	if f.PrepTimeMs > 0 {
+		return time.Duration(f.PrepTimeMs) * time.Millisecond
+	}
+	return defaultPrepTime
+}
+
 // IsDeleted checks if the food item has been soft deleted
 // Time Complexity: O(1)
 func (f *Food) IsDeleted() bool {
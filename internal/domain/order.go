@@ -3,12 +3,17 @@ package domain
 import "time"
 
 // OrderStatus represents the current status of an order
+// With a pipeline, PENDING and SERVING refer to the order's current stage (see Order.Stage);
+// an order is COMPLETE once its last stage is done
+// An order whose cooking failed too often is FAILED until it is requeued manually
 type OrderStatus string
 
 const (
-	OrderStatusPending  OrderStatus = "PENDING"
-	OrderStatusServing  OrderStatus = "SERVING"
-	OrderStatusComplete OrderStatus = "COMPLETE"
+	OrderStatusPending   OrderStatus = "PENDING"
+	OrderStatusServing   OrderStatus = "SERVING"
+	OrderStatusComplete  OrderStatus = "COMPLETE"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusFailed    OrderStatus = "FAILED"
 )
 
 // Order represents an order entity in the system
@@ -21,12 +26,38 @@ type Order struct {
 	CreatedAt         time.Time   `json:"created_at" db:"created_at"`
 	ModifiedAt        time.Time   `json:"modified_at" db:"modified_at"`
 	DeletedAt         *time.Time  `json:"deleted_at,omitempty" db:"deleted_at"`
+	Deadline          *time.Time  `json:"deadline,omitempty" db:"deadline"` // SLA target set at creation (nil = no SLA)
+	SLABreached       bool        `json:"sla_breached" db:"sla_breached"` // Completed after its deadline
+	Stage             string      `json:"stage,omitempty" db:"stage"` // Pipeline stage the order is in (empty = no pipeline)
+	Attempts          int         `json:"attempts" db:"attempts"` // Failed cooking attempts since the order was last (re)queued by hand
 
 	// Additional fields for enriched responses (not in DB)
 	CustomerName      string      `json:"customer_name,omitempty" db:"-"`
 	CustomerRole      RoleType    `json:"customer_role,omitempty" db:"-"`
 	CookName          string      `json:"cook_name,omitempty" db:"-"`
 	Foods             []Food      `json:"foods,omitempty" db:"-"`
+	Stages            []OrderStage `json:"stages,omitempty" db:"-"` // Who handled each pipeline stage and when
+
+	// Queue estimates for order lookups (computed on read, not in DB)
+	QueuePosition         *int       `json:"queue_position,omitempty" db:"-"`          // 1-based place in line (PENDING only)
+	EstimatedStartAt      *time.Time `json:"estimated_start_at,omitempty" db:"-"`      // When a cook is expected to take the order
+	EstimatedCompletionAt *time.Time `json:"estimated_completion_at,omitempty" db:"-"` // When the order is expected to be COMPLETE
+}
+
+// OrderStage records one cook's turn at an order in a pipeline stage
+// A turn cut short (cook removed, shutdown, stuck order recovered) keeps CompletedAt nil and the stage is started again
+type OrderStage struct {
+	Stage       string     `json:"stage" db:"stage"`
+	CookID      int        `json:"cook_id" db:"cook_id"`
+	CookName    string     `json:"cook_name,omitempty" db:"-"`
+	StartedAt   time.Time  `json:"started_at" db:"started_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// PipelineStage is one step of the order pipeline (e.g. prep, cook, pack), with its own queue and cooks
+type PipelineStage struct {
+	Name     string
+	Duration time.Duration // Time a cook spends on an order in this stage (0 = derived from the order's items)
 }
 
 // IsPending checks if the order is in pending status
@@ -47,18 +78,51 @@ func (o *Order) IsComplete() bool {
 	return o.Status == OrderStatusComplete
 }
 
+// IsCancelled checks if the order was cancelled before a cook took it
+// Time Complexity: O(1)
+func (o *Order) IsCancelled() bool {
+	return o.Status == OrderStatusCancelled
+}
+
+// IsFailed checks if the order failed too often and waits to be requeued
+// Time Complexity: O(1)
+func (o *Order) IsFailed() bool {
+	return o.Status == OrderStatusFailed
+}
+
 // IsDeleted checks if the order has been soft deleted
 // Time Complexity: O(1)
 func (o *Order) IsDeleted() bool {
 	return o.DeletedAt != nil
 }
 
+// HasDeadline checks if the order has an SLA deadline
+// Time Complexity: O(1)
+func (o *Order) HasDeadline() bool {
+	return o.Deadline != nil
+}
+
+// IsPastDeadline checks if the SLA deadline has passed at the given time
+// Time Complexity: O(1)
+func (o *Order) IsPastDeadline(now time.Time) bool {
+	return o.Deadline != nil && now.After(*o.Deadline)
+}
+
 // HasAssignedCook checks if the order has an assigned cook
 // Time Complexity: O(1)
 func (o *Order) HasAssignedCook() bool {
 	return o.AssignedCookUser != nil
 }
 
+// OrderStats holds order counts for monitoring
+type OrderStats struct {
+	Completed   int `json:"completed"`    // Orders with status COMPLETE
+	Incomplete  int `json:"incomplete"`   // Orders still PENDING or SERVING
+	Failed      int `json:"failed"`       // Orders with status FAILED (out of retries, waiting to be requeued)
+	SLABreached int `json:"sla_breached"` // Orders completed after their SLA deadline
+	Recovered   int `json:"recovered"`    // Stuck SERVING orders returned to the queue (by this process, since start)
+}
+
 // OrderFood represents the many-to-many relationship between orders and foods
 type OrderFood struct {
 	ID         int        `json:"id" db:"id"`
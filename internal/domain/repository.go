@@ -75,13 +75,41 @@ type OrderRepository interface {
 	// Time Complexity: O(1) for in-memory, O(log n) for database with index
 	UpdateStatus(ctx context.Context, orderID int, status OrderStatus) error
 
+	// SoftDelete soft deletes an order (sets DeletedAt timestamp), e.g. one refused at admission
+	// Time Complexity: O(1) for in-memory, O(log n) for database with index
+	SoftDelete(ctx context.Context, orderID int) error
+
 	// GetPendingOrders retrieves all pending orders (for queue initialization)
 	// Time Complexity: O(n) - must scan all orders
 	GetPendingOrders(ctx context.Context) ([]*Order, error)
 
-	// GetStats retrieves order statistics (completed/incomplete counts)
+	// MarkSLABreached records that an order missed its SLA deadline
+	// Time Complexity: O(1) for in-memory, O(log n) for database with index
+	MarkSLABreached(ctx context.Context, orderID int) error
+
+	// IncrementAttempts records a failed cooking attempt and returns the order's failed attempts so far
+	// Time Complexity: O(1) for in-memory, O(log n) for database with index
+	IncrementAttempts(ctx context.Context, orderID int) (int, error)
+
+	// ResetAttempts clears an order's failed attempts (when it is requeued by hand)
+	// Time Complexity: O(1) for in-memory, O(log n) for database with index
+	ResetAttempts(ctx context.Context, orderID int) error
+
+	// SetStage moves an order to another pipeline stage
+	// Time Complexity: O(1) for in-memory, O(log n) for database with index
+	SetStage(ctx context.Context, orderID int, stage string) error
+
+	// StartStage records that a cook started on an order's stage (GetByID returns the records in Stages)
+	// Time Complexity: O(1) for in-memory, O(log n) for database with index
+	StartStage(ctx context.Context, orderID int, stage string, cookID int) error
+
+	// CompleteStage records that the cook's current turn at an order's stage is done
+	// Time Complexity: O(s) for in-memory where s is the order's stage records, O(log n) for database with index
+	CompleteStage(ctx context.Context, orderID int, stage string) error
+
+	// GetStats retrieves order statistics (completed/incomplete counts; cancelled orders count as neither)
 	// Time Complexity: O(n) - must scan all orders
-	GetStats(ctx context.Context) (completed, incomplete int, err error)
+	GetStats(ctx context.Context) (OrderStats, error)
 }
 
 // FoodRepository defines the interface for food data access
@@ -20,8 +20,33 @@ type User struct {
 	CreatedAt  time.Time `json:"created_at" db:"created_at"`
 	ModifiedAt time.Time `json:"modified_at" db:"modified_at"`
 	DeletedAt  *time.Time `json:"deleted_at,omitempty" db:"deleted_at"` // Soft delete support
+	Capacity   int       `json:"capacity,omitempty" db:"capacity"` // Concurrent orders a cook can serve (0 = service default)
+	Capabilities []FoodType `json:"capabilities,omitempty" db:"capabilities"` // Food types a cook can prepare (empty = every type)
+	CompletionMode CompletionMode `json:"completion_mode,omitempty" db:"completion_mode"` // How the cook's orders are completed (empty = timer)
+	Stage string `json:"stage,omitempty" db:"stage"` // Pipeline stage the cook works (empty = first stage)
+
+	// Cook load for listings (computed on read, not in DB)
+	CurrentLoad *int `json:"current_load,omitempty" db:"-"` // Orders the cook is serving right now
+	WorkerState WorkerState `json:"worker_state,omitempty" db:"-"` // What the cook's worker is doing right now
 }
 
+// WorkerState describes whether a cook is taking new orders (process state, not stored in DB)
+type WorkerState string
+
+const (
+	WorkerStateRunning WorkerState = "running" // Worker takes orders as slots free up
+	WorkerStatePaused  WorkerState = "paused"  // Finishing current orders, taking nothing new
+	WorkerStateStopped WorkerState = "stopped" // No worker (manual accepts only, or removed)
+)
+
+// CompletionMode describes how a cook's orders move from SERVING to COMPLETE
+type CompletionMode string
+
+const (
+	CompletionModeTimer  CompletionMode = "timer"  // Completed once the order's prep time has passed (cook bots)
+	CompletionModeManual CompletionMode = "manual" // Completed when the cook reports it done (staff on a tablet)
+)
+
 // IsCustomer checks if the user is a customer (Regular or VIP)
 // Time Complexity: O(1)
 func (u *User) IsCustomer() bool {
@@ -40,6 +65,21 @@ func (u *User) IsCook() bool {
 	return u.Role == RoleCook
 }
 
+// EffectiveCapacity returns the cook's own capacity, or defaultCapacity when none was set
+// Time Complexity: O(1)
+func (u *User) EffectiveCapacity(defaultCapacity int) int {
+	if u.Capacity > 0 {
+		return u.Capacity
+	}
+	return defaultCapacity
+}
+
+// CompletesManually checks if the cook's orders stay SERVING until the cook completes them
+// Time Complexity: O(1)
+func (u *User) CompletesManually() bool {
+	return u.CompletionMode == CompletionModeManual
+}
+
 // IsDeleted checks if the user has been soft deleted
 // Time Complexity: O(1)
 func (u *User) IsDeleted() bool {
@@ -13,12 +13,13 @@ import (
 // Following Repository Pattern: abstracts data access
 // Time Complexity: Most operations are O(1) due to map usage
 type OrderRepository struct {
-	orders     map[int]*domain.Order // Map for O(1) lookup by ID
-	orderFoods map[int][]int         // Map of order ID to food IDs
-	mu         sync.RWMutex          // Protects concurrent access
-	nextID     int                   // Auto-increment ID
-	userRepo   domain.UserRepository // Dependency injection for user data
-	foodRepo   domain.FoodRepository // Dependency injection for food data
+	orders     map[int]*domain.Order       // Map for O(1) lookup by ID
+	orderFoods map[int][]int               // Map of order ID to food IDs
+	stages     map[int][]domain.OrderStage // Map of order ID to its pipeline stage records
+	mu         sync.RWMutex                // Protects concurrent access
+	nextID     int                         // Auto-increment ID
+	userRepo   domain.UserRepository       // Dependency injection for user data
+	foodRepo   domain.FoodRepository       // Dependency injection for food data
 }
 
 // NewOrderRepository creates a new in-memory order repository
@@ -26,6 +27,7 @@ func NewOrderRepository(userRepo domain.UserRepository, foodRepo domain.FoodRepo
 	return &OrderRepository{
 		orders:     make(map[int]*domain.Order),
 		orderFoods: make(map[int][]int),
+		stages:     make(map[int][]domain.OrderStage),
 		nextID:     1,
 		userRepo:   userRepo,
 		foodRepo:   foodRepo,
@@ -56,16 +58,46 @@ func (r *OrderRepository) Create(ctx context.Context, order *domain.Order, foodI
 	return order, nil
 }
 
+// Restore re-creates an order saved by a previous run under its original ID (queue snapshot at startup)
+// The order is stored as saved, foods, customer and stage records included, since a fresh repository
+// may hold neither the foods nor the customer; IDs handed out later continue after it
+// Time Complexity: O(s) where s is the number of stage records of the order
+func (r *OrderRepository) Restore(ctx context.Context, order *domain.Order) (*domain.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.orders[order.ID]; exists {
+		return nil, fmt.Errorf("order already exists: %d", order.ID)
+	}
+
+	stored := *order
+	stored.Stages = nil // Kept in r.stages like those of created orders
+	stored.QueuePosition, stored.EstimatedStartAt, stored.EstimatedCompletionAt = nil, nil, nil
+	r.orders[stored.ID] = &stored
+	if len(order.Stages) > 0 {
+		r.stages[stored.ID] = append([]domain.OrderStage(nil), order.Stages...)
+	}
+	if stored.ID >= r.nextID {
+		r.nextID = stored.ID + 1
+	}
+
+	restored := stored
+	restored.Stages = order.Stages
+	return &restored, nil
+}
+
 // GetByID retrieves an order by ID with enriched data
+// Returns a copy: enrichment happens under the read lock, so it must never write to the stored order
 // Time Complexity: O(1) for order lookup + O(n) for foods where n is number of foods per order
 func (r *OrderRepository) GetByID(ctx context.Context, id int) (*domain.Order, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
-	order, exists := r.orders[id]
+	stored, exists := r.orders[id]
 	if !exists {
 		return nil, fmt.Errorf("order not found: %d", id)
 	}
+	order := *stored
 
 	// Enrich with customer data
 	if customer, err := r.userRepo.GetByID(ctx, order.OrderedBy); err == nil {
@@ -91,7 +123,19 @@ func (r *OrderRepository) GetByID(ctx context.Context, id int) (*domain.Order, e
 		order.Foods = foods
 	}
 
-	return order, nil
+	// Enrich with pipeline stage records (copied: the stored records keep changing)
+	if records, exists := r.stages[id]; exists {
+		stages := make([]domain.OrderStage, len(records))
+		copy(stages, records)
+		for i := range stages {
+			if cook, err := r.userRepo.GetByID(ctx, stages[i].CookID); err == nil {
+				stages[i].CookName = cook.Name
+			}
+		}
+		order.Stages = stages
+	}
+
+	return &order, nil
 }
 
 // GetByStatus retrieves all orders with a specific status
@@ -205,29 +249,152 @@ func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID int, status
 	return nil
 }
 
+// SoftDelete soft deletes an order
+// Time Complexity: O(1) - map lookup and update
+func (r *OrderRepository) SoftDelete(ctx context.Context, orderID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	now := time.Now()
+	order.DeletedAt = &now
+	order.ModifiedAt = now
+	return nil
+}
+
 // GetPendingOrders retrieves all pending orders
 // Time Complexity: O(n) - must scan all orders
 func (r *OrderRepository) GetPendingOrders(ctx context.Context) ([]*domain.Order, error) {
 	return r.GetByStatus(ctx, domain.OrderStatusPending)
 }
 
+// MarkSLABreached records that an order missed its SLA deadline
+// Time Complexity: O(1) - map lookup and update
+func (r *OrderRepository) MarkSLABreached(ctx context.Context, orderID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	order.SLABreached = true
+	order.ModifiedAt = time.Now()
+	return nil
+}
+
+// IncrementAttempts records a failed cooking attempt
+// Time Complexity: O(1) - map lookup and update
+func (r *OrderRepository) IncrementAttempts(ctx context.Context, orderID int) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return 0, fmt.Errorf("order not found: %d", orderID)
+	}
+
+	order.Attempts++
+	order.ModifiedAt = time.Now()
+	return order.Attempts, nil
+}
+
+// ResetAttempts clears an order's failed attempts
+// Time Complexity: O(1) - map lookup and update
+func (r *OrderRepository) ResetAttempts(ctx context.Context, orderID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	order.Attempts = 0
+	order.ModifiedAt = time.Now()
+	return nil
+}
+
+// SetStage moves an order to another pipeline stage
+// Time Complexity: O(1) - map lookup and update
+func (r *OrderRepository) SetStage(ctx context.Context, orderID int, stage string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	order, exists := r.orders[orderID]
+	if !exists {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	order.Stage = stage
+	order.ModifiedAt = time.Now()
+	return nil
+}
+
+// StartStage records that a cook started on an order's stage
+// Time Complexity: O(1) - map lookup and append
+func (r *OrderRepository) StartStage(ctx context.Context, orderID int, stage string, cookID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.orders[orderID]; !exists {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	r.stages[orderID] = append(r.stages[orderID], domain.OrderStage{Stage: stage, CookID: cookID, StartedAt: time.Now()})
+	return nil
+}
+
+// CompleteStage records that the current turn at an order's stage is done
+// Time Complexity: O(s) where s is the number of stage records of the order
+func (r *OrderRepository) CompleteStage(ctx context.Context, orderID int, stage string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := r.stages[orderID]
+	for i := len(records) - 1; i >= 0; i-- {
+		if records[i].Stage == stage && records[i].CompletedAt == nil {
+			now := time.Now()
+			records[i].CompletedAt = &now
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no open %s stage for order %d", stage, orderID)
+}
+
 // GetStats retrieves order statistics
 // Time Complexity: O(n) - must scan all orders
-func (r *OrderRepository) GetStats(ctx context.Context) (completed, incomplete int, err error) {
+func (r *OrderRepository) GetStats(ctx context.Context) (domain.OrderStats, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
 
+	var stats domain.OrderStats
 	for _, order := range r.orders {
 		if order.DeletedAt != nil {
 			continue
 		}
 
-		if order.Status == domain.OrderStatusComplete {
-			completed++
-		} else {
-			incomplete++
+		switch order.Status {
+		case domain.OrderStatusComplete:
+			stats.Completed++
+		case domain.OrderStatusCancelled:
+			// Cancelled orders will never be cooked - neither completed nor incomplete
+		case domain.OrderStatusFailed:
+			stats.Failed++
+		default:
+			stats.Incomplete++
+		}
+
+		if order.SLABreached {
+			stats.SLABreached++
 		}
 	}
 
-	return completed, incomplete, nil
+	return stats, nil
 }
@@ -22,13 +22,13 @@ func NewFoodRepository(db *sql.DB) *FoodRepository {
 // Create creates a new food item
 func (r *FoodRepository) Create(ctx context.Context, food *domain.Food) (*domain.Food, error) {
 	query := `
-		INSERT INTO food (name, type, created_at, modified_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO food (name, type, prep_time_ms, created_at, modified_at)
+		VALUES ($1, $2, $3, $4, $5)
 		RETURNING id
 	`
 
 	now := time.Now()
-	err := r.db.QueryRowContext(ctx, query, food.Name, food.Type, now, now).Scan(&food.ID)
+	err := r.db.QueryRowContext(ctx, query, food.Name, food.Type, food.PrepTimeMs, now, now).Scan(&food.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create food: %w", err)
 	}
@@ -41,14 +41,14 @@ func (r *FoodRepository) Create(ctx context.Context, food *domain.Food) (*domain
 // GetByID retrieves a food item by ID
 func (r *FoodRepository) GetByID(ctx context.Context, id int) (*domain.Food, error) {
 	query := `
-		SELECT id, name, type, created_at, modified_at, deleted_at
+		SELECT id, name, type, prep_time_ms, created_at, modified_at, deleted_at
 		FROM food
 		WHERE id = $1
 	`
 
 	food := &domain.Food{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&food.ID, &food.Name, &food.Type,
+		&food.ID, &food.Name, &food.Type, &food.PrepTimeMs,
 		&food.CreatedAt, &food.ModifiedAt, &food.DeletedAt,
 	)
 
@@ -66,7 +66,7 @@ func (r *FoodRepository) GetByID(ctx context.Context, id int) (*domain.Food, err
 // Time Complexity: O(n) where n is the number of food items
 func (r *FoodRepository) GetAll(ctx context.Context) ([]*domain.Food, error) {
 	query := `
-		SELECT id, name, type, created_at, modified_at, deleted_at
+		SELECT id, name, type, prep_time_ms, created_at, modified_at, deleted_at
 		FROM food
 		WHERE deleted_at IS NULL
 		ORDER BY id
@@ -82,7 +82,7 @@ func (r *FoodRepository) GetAll(ctx context.Context) ([]*domain.Food, error) {
 	for rows.Next() {
 		food := &domain.Food{}
 		if err := rows.Scan(
-			&food.ID, &food.Name, &food.Type,
+			&food.ID, &food.Name, &food.Type, &food.PrepTimeMs,
 			&food.CreatedAt, &food.ModifiedAt, &food.DeletedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan food: %w", err)
@@ -97,7 +97,7 @@ func (r *FoodRepository) GetAll(ctx context.Context) ([]*domain.Food, error) {
 // Time Complexity: O(n) where n is the number of food items (database filters via WHERE clause)
 func (r *FoodRepository) GetByType(ctx context.Context, foodType domain.FoodType) ([]*domain.Food, error) {
 	query := `
-		SELECT id, name, type, created_at, modified_at, deleted_at
+		SELECT id, name, type, prep_time_ms, created_at, modified_at, deleted_at
 		FROM food
 		WHERE type = $1 AND deleted_at IS NULL
 		ORDER BY id
@@ -113,7 +113,7 @@ func (r *FoodRepository) GetByType(ctx context.Context, foodType domain.FoodType
 	for rows.Next() {
 		food := &domain.Food{}
 		if err := rows.Scan(
-			&food.ID, &food.Name, &food.Type,
+			&food.ID, &food.Name, &food.Type, &food.PrepTimeMs,
 			&food.CreatedAt, &food.ModifiedAt, &food.DeletedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan food: %w", err)
@@ -127,7 +127,7 @@ func (r *FoodRepository) GetByType(ctx context.Context, foodType domain.FoodType
 // GetByOrderID retrieves all food items for an order
 func (r *FoodRepository) GetByOrderID(ctx context.Context, orderID int) ([]*domain.Food, error) {
 	query := `
-		SELECT f.id, f.name, f.type, f.created_at, f.modified_at, f.deleted_at
+		SELECT f.id, f.name, f.type, f.prep_time_ms, f.created_at, f.modified_at, f.deleted_at
 		FROM food f
 		INNER JOIN order_food of ON f.id = of.food_id
 		WHERE of.order_id = $1 AND of.deleted_at IS NULL
@@ -144,7 +144,7 @@ func (r *FoodRepository) GetByOrderID(ctx context.Context, orderID int) ([]*doma
 	for rows.Next() {
 		food := &domain.Food{}
 		if err := rows.Scan(
-			&food.ID, &food.Name, &food.Type,
+			&food.ID, &food.Name, &food.Type, &food.PrepTimeMs,
 			&food.CreatedAt, &food.ModifiedAt, &food.DeletedAt,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan food: %w", err)
@@ -0,0 +1,791 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/logger"
+	"mcmocknald-order-kiosk/pkg/queue"
+
+	"github.com/lib/pq"
+)
+
+const (
+	// orderQueueChannel is the LISTEN/NOTIFY channel used to wake waiting workers on every instance
+	orderQueueChannel = "order_queue"
+
+	// orderQueuePollInterval bounds how long a waiting worker sleeps if a notification is missed
+	// (e.g. while the listener reconnects)
+	orderQueuePollInterval = 5 * time.Second
+
+	// orderQueueAdmitLock is the transaction-level advisory lock key serializing AdmitAndEnqueue
+	// across instances, so the depth count and the enqueue cannot interleave with another admission
+	orderQueueAdmitLock = 0x6f726471 // "ordq"
+)
+
+// enqueueQuery marks an order as queued at the back of its priority class ($1 = id, $2 = queue_priority)
+// The class follows the customer's role (queue_by_role), so Rerank may move it to another one
+const enqueueQuery = `
+	UPDATE "order"
+	SET queue_priority = $2, queued_at = NOW(), queue_by_role = TRUE
+	WHERE id = $1 AND queued_at IS NULL AND deleted_at IS NULL
+`
+
+// queuedOrderColumns selects a claimed/peeked order with its customer enrichment (matches scanQueuedOrder)
+const queuedOrderColumns = `
+	o.id, o.status, o.assigned_cook_user, o.ordered_by,
+	o.created_at, o.modified_at, o.deleted_at, o.deadline, o.sla_breached,
+	u.name as customer_name, u.role as customer_role
+`
+
+// OrderQueue implements queue.OrderQueue on top of the "order" table
+// An order is queued while queued_at IS NOT NULL; workers on any number of API instances
+// claim the next order with SELECT ... FOR UPDATE SKIP LOCKED, so one kitchen can be shared
+// Ordering: queue_priority (configured class, VIP first by default), then queued_at (the time of
+// Enqueue, moved ahead of the class head on EnqueueAtFront), then id
+// Following Repository Pattern: same interface as the in-memory PriorityQueue
+type OrderQueue struct {
+	db          *sql.DB
+	listener    *pq.Listener            // LISTEN order_queue: wakes waiters on enqueues from any instance
+	roleRank    map[domain.RoleType]int // Customer role -> queue_priority (class index)
+	nameRank    map[string]int          // Class name -> queue_priority
+	defaultRank int                     // queue_priority for roles mapped to no class
+	classNames  []string                // Class names by queue_priority (for admission errors)
+	limits      queue.AdmissionLimits   // Depth limits checked by Admit and AdmitAndEnqueue (shared by every instance)
+	events      queue.EventBus          // Change notifications for changes made through this instance
+	logger      logger.Logger           // Reports failures that do not fail the operation (e.g. NOTIFY)
+	mu          sync.Mutex              // Protects signal
+	signal      chan struct{}           // Closed and replaced on every enqueue notification (broadcast)
+	done        chan struct{}           // Closed by Close to stop the notification loop
+}
+
+// NewOrderQueue creates a Postgres-backed order queue and starts listening for enqueue notifications
+// The DSN is needed for the dedicated LISTEN connection (separate from the pooled db)
+// Time Complexity: O(c) where c is the number of priority classes
+func NewOrderQueue(db *sql.DB, dsn string, classes []queue.PriorityClass, limits queue.AdmissionLimits, log logger.Logger) (*OrderQueue, error) {
+	if len(classes) == 0 {
+		classes = queue.DefaultPriorityClasses()
+	}
+	if err := limits.ValidateClassCount(len(classes)); err != nil {
+		return nil, fmt.Errorf("invalid admission limits: %w", err)
+	}
+
+	q := &OrderQueue{
+		db:          db,
+		roleRank:    make(map[domain.RoleType]int),
+		nameRank:    make(map[string]int),
+		defaultRank: len(classes) - 1,
+		classNames:  make([]string, len(classes)),
+		limits:      limits,
+		logger:      log,
+		signal:      make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	for i, class := range classes {
+		q.classNames[i] = class.Name
+		q.nameRank[class.Name] = i
+		for _, role := range class.Roles {
+			q.roleRank[role] = i
+		}
+		if class.Default {
+			q.defaultRank = i
+		}
+	}
+
+	q.listener = pq.NewListener(dsn, time.Second, time.Minute, nil)
+	if err := q.listener.Listen(orderQueueChannel); err != nil {
+		q.listener.Close()
+		return nil, fmt.Errorf("failed to listen on %s: %w", orderQueueChannel, err)
+	}
+
+	go q.notificationLoop()
+
+	return q, nil
+}
+
+// Close stops listening for notifications and releases the LISTEN connection
+func (q *OrderQueue) Close() error {
+	close(q.done)
+	return q.listener.Close()
+}
+
+// notificationLoop wakes local waiters whenever any instance enqueues an order
+// A nil notification means the listener reconnected (notifications may have been missed)
+func (q *OrderQueue) notificationLoop() {
+	for {
+		select {
+		case <-q.listener.Notify:
+			q.broadcast()
+		case <-q.done:
+			return
+		}
+	}
+}
+
+// broadcast wakes every local DequeueWait caller; they race to claim with SKIP LOCKED
+// Time Complexity: O(1)
+func (q *OrderQueue) broadcast() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	close(q.signal)
+	q.signal = make(chan struct{})
+}
+
+// waitSignal returns the channel closed by the next broadcast
+// Time Complexity: O(1)
+func (q *OrderQueue) waitSignal() <-chan struct{} {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.signal
+}
+
+// rank resolves the queue_priority of an order from its customer role
+// Time Complexity: O(1)
+func (q *OrderQueue) rank(order *domain.Order) int {
+	if rank, exists := q.roleRank[order.CustomerRole]; exists {
+		return rank
+	}
+	return q.defaultRank
+}
+
+// Admit checks that a new order fits within the total and per-class depth limits
+// Counts orders queued by every instance; only a pre-check (see AdmitAndEnqueue)
+// Time Complexity: O(n) - partial index scan (skipped when no limits are configured)
+func (q *OrderQueue) Admit(order *domain.Order) error {
+	if order == nil {
+		return queue.ErrNilOrder
+	}
+
+	rank := q.rank(order)
+	if !q.limited(rank) {
+		return nil
+	}
+
+	return q.checkDepth(context.Background(), q.db, rank)
+}
+
+// limited reports whether a total or per-class depth limit applies to orders of a class
+// Time Complexity: O(1)
+func (q *OrderQueue) limited(rank int) bool {
+	classLimit := 0
+	if rank < len(q.limits.MaxClassDepths) {
+		classLimit = q.limits.MaxClassDepths[rank]
+	}
+	return q.limits.MaxDepth > 0 || classLimit > 0
+}
+
+// rowQuerier is satisfied by both *sql.DB and *sql.Tx
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// checkDepth counts the queued orders of every instance and checks them against the limits
+// Time Complexity: O(n) - partial index scan
+func (q *OrderQueue) checkDepth(ctx context.Context, db rowQuerier, rank int) error {
+	query := `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE queue_priority = $1)
+		FROM "order"
+		WHERE queued_at IS NOT NULL AND deleted_at IS NULL
+	`
+
+	var total, classDepth int
+	if err := db.QueryRowContext(ctx, query, rank).Scan(&total, &classDepth); err != nil {
+		return fmt.Errorf("failed to check queue depth: %w", err)
+	}
+
+	return q.limits.Check(total, q.classNames[rank], rank, classDepth)
+}
+
+// Enqueue marks an order as queued at the back of its priority class
+// Time Complexity: O(log n) - indexed update
+func (q *OrderQueue) Enqueue(order *domain.Order) error {
+	if order == nil {
+		return queue.ErrNilOrder
+	}
+
+	return q.mark(order, enqueueQuery, queue.EventEnqueued)
+}
+
+// AdmitAndEnqueue checks the depth limits and marks an order as queued in one transaction
+// An advisory lock serializes admissions of every instance, so concurrent admissions never overshoot a limit
+// Time Complexity: O(n) - partial index scan (plain Enqueue when no limits are configured)
+func (q *OrderQueue) AdmitAndEnqueue(order *domain.Order) error {
+	if order == nil {
+		return queue.ErrNilOrder
+	}
+
+	rank := q.rank(order)
+	if !q.limited(rank) {
+		return q.Enqueue(order)
+	}
+
+	ctx := context.Background()
+	tx, err := q.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SELECT pg_advisory_xact_lock($1)`, orderQueueAdmitLock); err != nil {
+		return fmt.Errorf("failed to lock queue admission: %w", err)
+	}
+
+	if err := q.checkDepth(ctx, tx, rank); err != nil {
+		return err
+	}
+
+	result, err := tx.ExecContext(ctx, enqueueQuery, order.ID, rank)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue order: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return q.enqueueConflict(ctx, order.ID)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	q.announce(ctx, order, queue.EventEnqueued)
+	return nil
+}
+
+// EnqueueAtFront marks an order as queued ahead of every order in its priority class
+// Time Complexity: O(log n) - indexed MIN lookup and update
+func (q *OrderQueue) EnqueueAtFront(order *domain.Order) error {
+	if order == nil {
+		return queue.ErrNilOrder
+	}
+
+	query := `
+		UPDATE "order"
+		SET queue_priority = $2, queue_by_role = FALSE,
+			queued_at = COALESCE(
+				(SELECT MIN(queued_at) FROM "order"
+				 WHERE queue_priority = $2 AND queued_at IS NOT NULL AND deleted_at IS NULL) - INTERVAL '1 microsecond',
+				NOW())
+		WHERE id = $1 AND queued_at IS NULL AND deleted_at IS NULL
+	`
+
+	return q.mark(order, query, queue.EventRequeuedAtFront)
+}
+
+// mark runs an enqueue update and notifies waiting workers on every instance
+// Time Complexity: O(log n)
+func (q *OrderQueue) mark(order *domain.Order, query string, eventType queue.EventType) error {
+	ctx := context.Background()
+
+	result, err := q.db.ExecContext(ctx, query, order.ID, q.rank(order))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue order: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return q.enqueueConflict(ctx, order.ID)
+	}
+
+	q.announce(ctx, order, eventType)
+	return nil
+}
+
+// announce notifies waiting workers on every instance and local subscribers of a queued order
+// Time Complexity: O(1)
+func (q *OrderQueue) announce(ctx context.Context, order *domain.Order, eventType queue.EventType) {
+	// The order is queued either way: waiters on other instances pick it up on their next poll
+	if _, err := q.db.ExecContext(ctx, `SELECT pg_notify($1, $2)`, orderQueueChannel, strconv.Itoa(order.ID)); err != nil {
+		q.logger.Error("Order %d queued but notifying other instances failed: %v", order.ID, err)
+	}
+	q.broadcast()
+	q.publish(eventType, order, q.rank(order))
+}
+
+// enqueueConflict explains why an enqueue update matched no row
+// Time Complexity: O(log n)
+func (q *OrderQueue) enqueueConflict(ctx context.Context, orderID int) error {
+	var queued bool
+	err := q.db.QueryRowContext(ctx,
+		`SELECT queued_at IS NOT NULL FROM "order" WHERE id = $1 AND deleted_at IS NULL`, orderID,
+	).Scan(&queued)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check order %d: %w", orderID, err)
+	}
+	if queued {
+		return queue.ErrDuplicateOrder
+	}
+	return fmt.Errorf("failed to enqueue order %d", orderID)
+}
+
+// Dequeue claims the next order (highest priority class, then FIFO)
+// Time Complexity: O(log n) - indexed scan skipping rows locked by other instances
+func (q *OrderQueue) Dequeue() (*domain.Order, error) {
+	return q.claim(context.Background(), nil)
+}
+
+// DequeueFor claims the next order whose every food item is of a type in caps
+// Time Complexity: O(log n) without capabilities, O(n) worst case when skipping unmatched orders
+func (q *OrderQueue) DequeueFor(caps queue.Capabilities) (*domain.Order, error) {
+	return q.claim(context.Background(), caps)
+}
+
+// claim atomically takes the next queued order the capabilities can prepare that no other transaction is claiming
+// The order leaves the queue already SERVING, so an instance that dies before assigning a cook leaves it to
+// the stuck-order reaper instead of stranding it PENDING outside the queue. The statement runs to completion
+// even if ctx is cancelled meanwhile (e.g. a pause interrupt): a committed claim is never dropped unread.
+// Time Complexity: O(log n) without capabilities
+func (q *OrderQueue) claim(ctx context.Context, caps queue.Capabilities) (*domain.Order, error) {
+	args := []any{domain.OrderStatusServing}
+
+	// Skip orders containing any food type the cook cannot prepare
+	var capabilityFilter string
+	if len(caps) > 0 {
+		capabilityFilter = `
+			AND NOT EXISTS (
+				SELECT 1 FROM order_food ofd
+				INNER JOIN food f ON f.id = ofd.food_id
+				WHERE ofd.order_id = "order".id AND ofd.deleted_at IS NULL
+				AND f.type <> ALL($2)
+			)`
+		args = append(args, pq.Array(caps.Strings()))
+	}
+
+	query := `
+		WITH next AS (
+			SELECT id FROM "order"
+			WHERE queued_at IS NOT NULL AND deleted_at IS NULL` + capabilityFilter + `
+			ORDER BY queue_priority, queued_at, id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		), claimed AS (
+			UPDATE "order" o
+			SET queue_priority = NULL, queued_at = NULL, queue_by_role = FALSE, status = $1, modified_at = NOW()
+			FROM next
+			WHERE o.id = next.id
+			RETURNING o.*
+		)
+		SELECT ` + queuedOrderColumns + `
+		FROM claimed o
+		INNER JOIN "user" u ON o.ordered_by = u.id
+	`
+
+	order, err := scanQueuedOrder(q.db.QueryRowContext(context.WithoutCancel(ctx), query, args...))
+	if err == sql.ErrNoRows {
+		if len(caps) > 0 && !q.IsEmpty() {
+			return nil, queue.ErrNoMatchingOrder
+		}
+		return nil, queue.ErrEmptyQueue
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim order: %w", err)
+	}
+
+	q.publish(queue.EventDequeued, order, q.rank(order))
+	return order, nil
+}
+
+// DequeueWait claims the next order, blocking until one is available
+// Idle callers wait for a NOTIFY from any instance, with a periodic poll as a safety net
+// Time Complexity: O(log n) per wake-up
+func (q *OrderQueue) DequeueWait(ctx context.Context) (*domain.Order, error) {
+	return q.DequeueWaitFor(ctx, nil)
+}
+
+// DequeueWaitFor claims the next order the capabilities can prepare, blocking until one is available
+// Every enqueue wakes all local waiters; those that cannot prepare the new order go back to sleep
+// Time Complexity: O(log n) per wake-up without capabilities
+func (q *OrderQueue) DequeueWaitFor(ctx context.Context, caps queue.Capabilities) (*domain.Order, error) {
+	ticker := time.NewTicker(orderQueuePollInterval)
+	defer ticker.Stop()
+
+	for {
+		// Take the signal before claiming so an enqueue in between is not missed
+		wake := q.waitSignal()
+
+		order, err := q.claim(ctx, caps)
+		if err == nil {
+			return order, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if !errors.Is(err, queue.ErrEmptyQueue) && !errors.Is(err, queue.ErrNoMatchingOrder) {
+			return nil, err
+		}
+
+		select {
+		case <-wake:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// RequeueOrphans enqueues PENDING orders that are in no queue, returning how many were requeued
+// Orders are only left like this by an instance that stopped between updating an order and enqueuing it
+// (e.g. after creating it, or while returning it from a cook). They go to the back of their class, like any
+// Enqueue. Orders unchanged for less than grace are skipped so enqueues still in progress on other
+// instances are not raced.
+// Time Complexity: O(n + k log n) where k is the number of orphaned orders
+func (q *OrderQueue) RequeueOrphans(ctx context.Context, grace time.Duration) (int, error) {
+	query := `
+		SELECT ` + queuedOrderColumns + `
+		FROM "order" o
+		INNER JOIN "user" u ON o.ordered_by = u.id
+		WHERE o.status = $1 AND o.queued_at IS NULL AND o.deleted_at IS NULL
+		AND o.modified_at < NOW() - make_interval(secs => $2)
+		ORDER BY o.created_at, o.id
+	`
+
+	rows, err := q.db.QueryContext(ctx, query, domain.OrderStatusPending, grace.Seconds())
+	if err != nil {
+		return 0, fmt.Errorf("failed to find orphaned orders: %w", err)
+	}
+
+	var orphans []*domain.Order
+	for rows.Next() {
+		order := &domain.Order{}
+		if err := rows.Scan(queuedOrderFields(order)...); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan orphaned order: %w", err)
+		}
+		orphans = append(orphans, order)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to find orphaned orders: %w", err)
+	}
+
+	requeued := 0
+	for _, order := range orphans {
+		if err := q.Enqueue(order); err != nil {
+			if errors.Is(err, queue.ErrDuplicateOrder) {
+				continue // Requeued by another instance meanwhile
+			}
+			return requeued, err
+		}
+		requeued++
+	}
+
+	return requeued, nil
+}
+
+// Rerank recomputes queue_priority of queued orders from the configured classes, returning how many changed
+// Run on startup: migration 006 ranked the orders queued at upgrade time by the default classes, and
+// QUEUE_PRIORITY_CLASSES may have changed since orders were queued. Only orders ranked by their customer's
+// role (queue_by_role, set by Enqueue) are ranked again; orders expedited, reclassified or requeued at
+// the front were placed deliberately and keep their class.
+// Time Complexity: O(n) where n is the number of queued orders
+func (q *OrderQueue) Rerank(ctx context.Context) (int, error) {
+	roles := make([]string, 0, len(q.roleRank))
+	ranks := make([]int64, 0, len(q.roleRank))
+	for role, rank := range q.roleRank {
+		roles = append(roles, string(role))
+		ranks = append(ranks, int64(rank))
+	}
+
+	query := `
+		UPDATE "order" o
+		SET queue_priority = COALESCE(r.rank, $1)
+		FROM "user" u
+		LEFT JOIN unnest($2::text[], $3::int[]) AS r(role, rank) ON r.role = u.role
+		WHERE o.ordered_by = u.id
+		AND o.queued_at IS NOT NULL AND o.queue_by_role AND o.deleted_at IS NULL
+		AND o.queue_priority IS DISTINCT FROM COALESCE(r.rank, $1)
+	`
+
+	result, err := q.db.ExecContext(ctx, query, q.defaultRank, pq.Array(roles), pq.Array(ranks))
+	if err != nil {
+		return 0, fmt.Errorf("failed to rerank queued orders: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return int(rows), nil
+}
+
+// Remove takes a specific order out of the queue; fails if another worker already claimed it
+// Time Complexity: O(log n)
+func (q *OrderQueue) Remove(orderID int) (*domain.Order, error) {
+	query := `
+		WITH removed AS (
+			UPDATE "order"
+			SET queue_priority = NULL, queued_at = NULL, queue_by_role = FALSE
+			WHERE id = $1 AND queued_at IS NOT NULL AND deleted_at IS NULL
+			RETURNING *
+		)
+		SELECT ` + queuedOrderColumns + `
+		FROM removed o
+		INNER JOIN "user" u ON o.ordered_by = u.id
+	`
+
+	order, err := scanQueuedOrder(q.db.QueryRowContext(context.Background(), query, orderID))
+	if err == sql.ErrNoRows {
+		return nil, queue.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove order: %w", err)
+	}
+
+	q.publish(queue.EventRemoved, order, q.rank(order))
+	return order, nil
+}
+
+// Position returns the 1-based place in line of a queued order across all instances
+// Time Complexity: O(k) where k is the number of orders ahead of it
+func (q *OrderQueue) Position(orderID int) (int, error) {
+	query := `
+		WITH target AS (
+			SELECT queue_priority, queued_at, id FROM "order"
+			WHERE id = $1 AND queued_at IS NOT NULL AND deleted_at IS NULL
+		)
+		SELECT COUNT(o.id) + 1
+		FROM target t
+		LEFT JOIN "order" o
+			ON o.queued_at IS NOT NULL AND o.deleted_at IS NULL
+			AND (o.queue_priority, o.queued_at, o.id) < (t.queue_priority, t.queued_at, t.id)
+		GROUP BY t.id
+	`
+
+	var position int
+	err := q.db.QueryRowContext(context.Background(), query, orderID).Scan(&position)
+	if err == sql.ErrNoRows {
+		return 0, queue.ErrOrderNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue position: %w", err)
+	}
+
+	return position, nil
+}
+
+// Snapshot returns the queued orders of every priority class (across all instances), front first
+// Time Complexity: O(n) - partial index scan in queue order
+func (q *OrderQueue) Snapshot() ([]queue.ClassSnapshot, error) {
+	query := `
+		SELECT o.queue_priority, o.queued_at, ` + queuedOrderColumns + `
+		FROM "order" o
+		INNER JOIN "user" u ON o.ordered_by = u.id
+		WHERE o.queued_at IS NOT NULL AND o.deleted_at IS NULL
+		ORDER BY o.queue_priority, o.queued_at, o.id
+	`
+
+	rows, err := q.db.QueryContext(context.Background(), query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list queued orders: %w", err)
+	}
+	defer rows.Close()
+
+	snapshot := make([]queue.ClassSnapshot, len(q.classNames))
+	for i, name := range q.classNames {
+		snapshot[i] = queue.ClassSnapshot{Name: name}
+	}
+
+	for rows.Next() {
+		var rank int
+		var queuedAt time.Time
+		order := &domain.Order{}
+		dest := append([]any{&rank, &queuedAt}, queuedOrderFields(order)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("failed to scan queued order: %w", err)
+		}
+		if rank < 0 || rank >= len(snapshot) {
+			rank = q.defaultRank
+		}
+		snapshot[rank].Orders = append(snapshot[rank].Orders, queue.QueuedOrder{Order: order, EnqueuedAt: queuedAt})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list queued orders: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// MoveToFront moves a queued order ahead of every other order in its class
+// Time Complexity: O(log n) - indexed MIN lookup and update
+func (q *OrderQueue) MoveToFront(orderID int) error {
+	query := `
+		UPDATE "order" o
+		SET queue_by_role = FALSE, queued_at = (
+			SELECT MIN(c.queued_at) FROM "order" c
+			WHERE c.queue_priority = o.queue_priority AND c.queued_at IS NOT NULL AND c.deleted_at IS NULL
+		) - INTERVAL '1 microsecond'
+		WHERE o.id = $1 AND o.queued_at IS NOT NULL AND o.deleted_at IS NULL
+		RETURNING o.queue_priority
+	`
+
+	return q.requeue(orderID, queue.EventMovedToFront, query, orderID)
+}
+
+// SetClass moves a queued order to the back of another priority class
+// Time Complexity: O(log n)
+func (q *OrderQueue) SetClass(orderID int, className string) error {
+	rank, exists := q.nameRank[className]
+	if !exists {
+		return fmt.Errorf("%w: %s", queue.ErrUnknownClass, className)
+	}
+
+	query := `
+		UPDATE "order"
+		SET queue_priority = $2, queued_at = NOW(), queue_by_role = FALSE
+		WHERE id = $1 AND queued_at IS NOT NULL AND deleted_at IS NULL AND queue_priority <> $2
+		RETURNING queue_priority
+	`
+
+	return q.requeue(orderID, queue.EventClassChanged, query, orderID, rank)
+}
+
+// requeue runs an update that moves an already-queued order and publishes the change
+// An update matching no row means the order is not queued (or already in the requested class)
+// Time Complexity: O(log n)
+func (q *OrderQueue) requeue(orderID int, eventType queue.EventType, query string, args ...any) error {
+	ctx := context.Background()
+
+	var rank int
+	err := q.db.QueryRowContext(ctx, query, args...).Scan(&rank)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("failed to reorder queue: %w", err)
+	}
+	if err == sql.ErrNoRows {
+		var queued bool
+		err := q.db.QueryRowContext(ctx,
+			`SELECT queued_at IS NOT NULL FROM "order" WHERE id = $1 AND deleted_at IS NULL`, orderID,
+		).Scan(&queued)
+		if err == sql.ErrNoRows || (err == nil && !queued) {
+			return queue.ErrOrderNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check order %d: %w", orderID, err)
+		}
+		return nil // Already in the requested class
+	}
+
+	if q.events.HasSubscribers() {
+		order := &domain.Order{ID: orderID}
+		if queued, err := q.queuedOrder(ctx, orderID); err == nil {
+			order = queued
+		}
+		q.publish(eventType, order, rank)
+	}
+
+	return nil
+}
+
+// queuedOrder loads a queued order with its customer enrichment
+// Time Complexity: O(log n)
+func (q *OrderQueue) queuedOrder(ctx context.Context, orderID int) (*domain.Order, error) {
+	query := `
+		SELECT ` + queuedOrderColumns + `
+		FROM "order" o
+		INNER JOIN "user" u ON o.ordered_by = u.id
+		WHERE o.id = $1
+	`
+	return scanQueuedOrder(q.db.QueryRowContext(ctx, query, orderID))
+}
+
+// Subscribe returns a subscription to changes made through this instance
+// Changes made by other instances are not reported (they only arrive as LISTEN wake-ups)
+// Time Complexity: O(1)
+func (q *OrderQueue) Subscribe(buffer int) *queue.Subscription {
+	return q.events.Subscribe(buffer)
+}
+
+// publish notifies local subscribers of a change; the queue size is only counted if someone is listening
+// rank is the order's queue_priority (class index)
+// Time Complexity: O(n) with subscribers (Size query), O(1) otherwise
+func (q *OrderQueue) publish(eventType queue.EventType, order *domain.Order, rank int) {
+	if !q.events.HasSubscribers() {
+		return
+	}
+
+	q.events.Publish(queue.Event{
+		Type:  eventType,
+		Order: order,
+		Class: q.classNames[rank],
+		Size:  q.Size(),
+		At:    time.Now(),
+	})
+}
+
+// Size returns the number of queued orders across all instances (0 if the query fails)
+// Time Complexity: O(n) - partial index scan
+func (q *OrderQueue) Size() int {
+	var size int
+	err := q.db.QueryRowContext(context.Background(),
+		`SELECT COUNT(*) FROM "order" WHERE queued_at IS NOT NULL AND deleted_at IS NULL`,
+	).Scan(&size)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// IsEmpty checks if no orders are queued
+// Time Complexity: O(1) - stops at the first queued row
+func (q *OrderQueue) IsEmpty() bool {
+	var exists bool
+	err := q.db.QueryRowContext(context.Background(),
+		`SELECT EXISTS (SELECT 1 FROM "order" WHERE queued_at IS NOT NULL AND deleted_at IS NULL)`,
+	).Scan(&exists)
+	return err != nil || !exists
+}
+
+// Peek returns the next order without claiming it (another instance may claim it first)
+// Time Complexity: O(log n)
+func (q *OrderQueue) Peek() (*domain.Order, error) {
+	query := `
+		SELECT ` + queuedOrderColumns + `
+		FROM "order" o
+		INNER JOIN "user" u ON o.ordered_by = u.id
+		WHERE o.queued_at IS NOT NULL AND o.deleted_at IS NULL
+		ORDER BY o.queue_priority, o.queued_at, o.id
+		LIMIT 1
+	`
+
+	order, err := scanQueuedOrder(q.db.QueryRowContext(context.Background(), query))
+	if err == sql.ErrNoRows {
+		return nil, queue.ErrEmptyQueue
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to peek order: %w", err)
+	}
+
+	return order, nil
+}
+
+// scanQueuedOrder scans a row selected with queuedOrderColumns
+func scanQueuedOrder(row *sql.Row) (*domain.Order, error) {
+	order := &domain.Order{}
+	if err := row.Scan(queuedOrderFields(order)...); err != nil {
+		return nil, err
+	}
+	return order, nil
+}
+
+// queuedOrderFields returns the scan destinations matching queuedOrderColumns
+func queuedOrderFields(order *domain.Order) []any {
+	return []any{
+		&order.ID, &order.Status, &order.AssignedCookUser, &order.OrderedBy,
+		&order.CreatedAt, &order.ModifiedAt, &order.DeletedAt, &order.Deadline, &order.SLABreached,
+		&order.CustomerName, &order.CustomerRole,
+	}
+}
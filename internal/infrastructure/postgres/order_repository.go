@@ -32,8 +32,8 @@ func (r *OrderRepository) Create(ctx context.Context, order *domain.Order, foodI
 
 	// Insert order
 	query := `
-		INSERT INTO "order" (status, assigned_cook_user, ordered_by, created_at, modified_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO "order" (status, assigned_cook_user, ordered_by, deadline, stage, created_at, modified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 		RETURNING id
 	`
 
@@ -44,7 +44,7 @@ func (r *OrderRepository) Create(ctx context.Context, order *domain.Order, foodI
 
 	err = tx.QueryRowContext(
 		ctx, query,
-		order.Status, order.AssignedCookUser, order.OrderedBy, now, now,
+		order.Status, order.AssignedCookUser, order.OrderedBy, order.Deadline, order.Stage, now, now,
 	).Scan(&order.ID)
 
 	if err != nil {
@@ -81,7 +81,7 @@ func (r *OrderRepository) GetByID(ctx context.Context, id int) (*domain.Order, e
 	query := `
 		SELECT
 			o.id, o.status, o.assigned_cook_user, o.ordered_by,
-			o.created_at, o.modified_at, o.deleted_at,
+			o.created_at, o.modified_at, o.deleted_at, o.deadline, o.sla_breached, o.stage, o.attempts,
 			u.name as customer_name, u.role as customer_role,
 			COALESCE(c.name, '') as cook_name
 		FROM "order" o
@@ -93,7 +93,7 @@ func (r *OrderRepository) GetByID(ctx context.Context, id int) (*domain.Order, e
 	order := &domain.Order{}
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
 		&order.ID, &order.Status, &order.AssignedCookUser, &order.OrderedBy,
-		&order.CreatedAt, &order.ModifiedAt, &order.DeletedAt,
+		&order.CreatedAt, &order.ModifiedAt, &order.DeletedAt, &order.Deadline, &order.SLABreached, &order.Stage, &order.Attempts,
 		&order.CustomerName, &order.CustomerRole, &order.CookName,
 	)
 
@@ -106,7 +106,7 @@ func (r *OrderRepository) GetByID(ctx context.Context, id int) (*domain.Order, e
 
 	// Get associated foods
 	foodQuery := `
-		SELECT f.id, f.name, f.type, f.created_at, f.modified_at
+		SELECT f.id, f.name, f.type, f.prep_time_ms, f.created_at, f.modified_at
 		FROM food f
 		INNER JOIN order_food of ON f.id = of.food_id
 		WHERE of.order_id = $1 AND of.deleted_at IS NULL
@@ -121,13 +121,36 @@ func (r *OrderRepository) GetByID(ctx context.Context, id int) (*domain.Order, e
 	var foods []domain.Food
 	for rows.Next() {
 		food := domain.Food{}
-		if err := rows.Scan(&food.ID, &food.Name, &food.Type, &food.CreatedAt, &food.ModifiedAt); err != nil {
+		if err := rows.Scan(&food.ID, &food.Name, &food.Type, &food.PrepTimeMs, &food.CreatedAt, &food.ModifiedAt); err != nil {
 			return nil, fmt.Errorf("failed to scan food: %w", err)
 		}
 		foods = append(foods, food)
 	}
 	order.Foods = foods
 
+	// Get pipeline stage records
+	stageQuery := `
+		SELECT s.stage, s.cook_id, COALESCE(c.name, ''), s.started_at, s.completed_at
+		FROM order_stage s
+		LEFT JOIN "user" c ON s.cook_id = c.id
+		WHERE s.order_id = $1
+		ORDER BY s.id
+	`
+
+	stageRows, err := r.db.QueryContext(ctx, stageQuery, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order stages: %w", err)
+	}
+	defer stageRows.Close()
+
+	for stageRows.Next() {
+		stage := domain.OrderStage{}
+		if err := stageRows.Scan(&stage.Stage, &stage.CookID, &stage.CookName, &stage.StartedAt, &stage.CompletedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan order stage: %w", err)
+		}
+		order.Stages = append(order.Stages, stage)
+	}
+
 	return order, nil
 }
 
@@ -137,7 +160,7 @@ func (r *OrderRepository) GetByStatus(ctx context.Context, status domain.OrderSt
 	query := `
 		SELECT
 			o.id, o.status, o.assigned_cook_user, o.ordered_by,
-			o.created_at, o.modified_at, o.deleted_at,
+			o.created_at, o.modified_at, o.deleted_at, o.deadline, o.sla_breached, o.stage, o.attempts,
 			u.name as customer_name, u.role as customer_role
 		FROM "order" o
 		INNER JOIN "user" u ON o.ordered_by = u.id
@@ -160,7 +183,7 @@ func (r *OrderRepository) GetByCustomerID(ctx context.Context, customerID int) (
 	query := `
 		SELECT
 			o.id, o.status, o.assigned_cook_user, o.ordered_by,
-			o.created_at, o.modified_at, o.deleted_at,
+			o.created_at, o.modified_at, o.deleted_at, o.deadline, o.sla_breached, o.stage, o.attempts,
 			u.name as customer_name, u.role as customer_role
 		FROM "order" o
 		INNER JOIN "user" u ON o.ordered_by = u.id
@@ -183,7 +206,7 @@ func (r *OrderRepository) GetByCookID(ctx context.Context, cookID int) ([]*domai
 	query := `
 		SELECT
 			o.id, o.status, o.assigned_cook_user, o.ordered_by,
-			o.created_at, o.modified_at, o.deleted_at,
+			o.created_at, o.modified_at, o.deleted_at, o.deadline, o.sla_breached, o.stage, o.attempts,
 			u.name as customer_name, u.role as customer_role
 		FROM "order" o
 		INNER JOIN "user" u ON o.ordered_by = u.id
@@ -312,29 +335,206 @@ func (r *OrderRepository) UpdateStatus(ctx context.Context, orderID int, status
 	return nil
 }
 
+// SoftDelete soft deletes an order
+// Time Complexity: O(log n) with index on id
+func (r *OrderRepository) SoftDelete(ctx context.Context, orderID int) error {
+	query := `
+		UPDATE "order"
+		SET deleted_at = $1, modified_at = $2
+		WHERE id = $3 AND deleted_at IS NULL
+	`
+
+	now := time.Now()
+	result, err := r.db.ExecContext(ctx, query, now, now, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to soft delete order: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("order not found or already deleted: %d", orderID)
+	}
+
+	return nil
+}
+
 // GetPendingOrders retrieves all pending orders
 // Time Complexity: O(n) with index on status
 func (r *OrderRepository) GetPendingOrders(ctx context.Context) ([]*domain.Order, error) {
 	return r.GetByStatus(ctx, domain.OrderStatusPending)
 }
 
+// MarkSLABreached records that an order missed its SLA deadline
+// Time Complexity: O(log n) with index on id
+func (r *OrderRepository) MarkSLABreached(ctx context.Context, orderID int) error {
+	query := `
+		UPDATE "order"
+		SET sla_breached = TRUE, modified_at = $1
+		WHERE id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), orderID)
+	if err != nil {
+		return fmt.Errorf("failed to mark SLA breach: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	return nil
+}
+
+// IncrementAttempts records a failed cooking attempt
+// Time Complexity: O(log n) with index on id
+func (r *OrderRepository) IncrementAttempts(ctx context.Context, orderID int) (int, error) {
+	query := `
+		UPDATE "order"
+		SET attempts = attempts + 1, modified_at = $1
+		WHERE id = $2
+		RETURNING attempts
+	`
+
+	var attempts int
+	if err := r.db.QueryRowContext(ctx, query, time.Now(), orderID).Scan(&attempts); err != nil {
+		if err == sql.ErrNoRows {
+			return 0, fmt.Errorf("order not found: %d", orderID)
+		}
+		return 0, fmt.Errorf("failed to increment attempts: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// ResetAttempts clears an order's failed attempts
+// Time Complexity: O(log n) with index on id
+func (r *OrderRepository) ResetAttempts(ctx context.Context, orderID int) error {
+	query := `
+		UPDATE "order"
+		SET attempts = 0, modified_at = $1
+		WHERE id = $2
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), orderID)
+	if err != nil {
+		return fmt.Errorf("failed to reset attempts: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	return nil
+}
+
+// SetStage moves an order to another pipeline stage
+// Time Complexity: O(log n) with index on id
+func (r *OrderRepository) SetStage(ctx context.Context, orderID int, stage string) error {
+	query := `
+		UPDATE "order"
+		SET stage = $1, modified_at = $2
+		WHERE id = $3
+	`
+
+	result, err := r.db.ExecContext(ctx, query, stage, time.Now(), orderID)
+	if err != nil {
+		return fmt.Errorf("failed to set stage: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("order not found: %d", orderID)
+	}
+
+	return nil
+}
+
+// StartStage records that a cook started on an order's stage
+// Time Complexity: O(log n) for the insert
+func (r *OrderRepository) StartStage(ctx context.Context, orderID int, stage string, cookID int) error {
+	query := `
+		INSERT INTO order_stage (order_id, stage, cook_id, started_at)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	if _, err := r.db.ExecContext(ctx, query, orderID, stage, cookID, time.Now()); err != nil {
+		return fmt.Errorf("failed to start stage: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteStage records that the current turn at an order's stage is done
+// Time Complexity: O(log n) with index on order_id
+func (r *OrderRepository) CompleteStage(ctx context.Context, orderID int, stage string) error {
+	query := `
+		UPDATE order_stage
+		SET completed_at = $1
+		WHERE id = (
+			SELECT id FROM order_stage
+			WHERE order_id = $2 AND stage = $3 AND completed_at IS NULL
+			ORDER BY id DESC
+			LIMIT 1
+		)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, time.Now(), orderID, stage)
+	if err != nil {
+		return fmt.Errorf("failed to complete stage: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return fmt.Errorf("no open %s stage for order %d", stage, orderID)
+	}
+
+	return nil
+}
+
 // GetStats retrieves order statistics
 // Time Complexity: O(n) - scans all orders
-func (r *OrderRepository) GetStats(ctx context.Context) (completed, incomplete int, err error) {
+func (r *OrderRepository) GetStats(ctx context.Context) (domain.OrderStats, error) {
 	query := `
 		SELECT
 			COUNT(CASE WHEN status = $1 THEN 1 END) as completed,
-			COUNT(CASE WHEN status != $1 THEN 1 END) as incomplete
+			COUNT(CASE WHEN status NOT IN ($1, $2, $3) THEN 1 END) as incomplete,
+			COUNT(CASE WHEN status = $3 THEN 1 END) as failed,
+			COUNT(CASE WHEN sla_breached THEN 1 END) as sla_breached
 		FROM "order"
 		WHERE deleted_at IS NULL
 	`
 
-	err = r.db.QueryRowContext(ctx, query, domain.OrderStatusComplete).Scan(&completed, &incomplete)
+	var stats domain.OrderStats
+	err := r.db.QueryRowContext(ctx, query, domain.OrderStatusComplete, domain.OrderStatusCancelled, domain.OrderStatusFailed).Scan(
+		&stats.Completed, &stats.Incomplete, &stats.Failed, &stats.SLABreached,
+	)
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to get stats: %w", err)
+		return domain.OrderStats{}, fmt.Errorf("failed to get stats: %w", err)
 	}
 
-	return completed, incomplete, nil
+	return stats, nil
 }
 
 // Helper function to scan orders from rows
@@ -344,7 +544,7 @@ func (r *OrderRepository) scanOrders(rows *sql.Rows) ([]*domain.Order, error) {
 		order := &domain.Order{}
 		if err := rows.Scan(
 			&order.ID, &order.Status, &order.AssignedCookUser, &order.OrderedBy,
-			&order.CreatedAt, &order.ModifiedAt, &order.DeletedAt,
+			&order.CreatedAt, &order.ModifiedAt, &order.DeletedAt, &order.Deadline, &order.SLABreached, &order.Stage, &order.Attempts,
 			&order.CustomerName, &order.CustomerRole,
 		); err != nil {
 			return nil, fmt.Errorf("failed to scan order: %w", err)
@@ -7,8 +7,13 @@ import (
 	"time"
 
 	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/lib/pq"
 )
 
+// userColumns selects a user row (matches scanUser)
+const userColumns = `id, name, role, capacity, capabilities, completion_mode, stage, created_at, modified_at, deleted_at`
+
 // UserRepository implements PostgreSQL user repository
 // Following Repository Pattern: abstracts data access
 // Optimized with indexes for O(log n) lookup performance
@@ -25,15 +30,15 @@ func NewUserRepository(db *sql.DB) *UserRepository {
 // Time Complexity: O(log n) with index on id
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) (*domain.User, error) {
 	query := `
-		INSERT INTO "user" (name, role, created_at, modified_at)
-		VALUES ($1, $2, $3, $4)
+		INSERT INTO "user" (name, role, capacity, capabilities, completion_mode, stage, created_at, modified_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		RETURNING id
 	`
 
 	now := time.Now()
 	err := r.db.QueryRowContext(
 		ctx, query,
-		user.Name, user.Role, now, now,
+		user.Name, user.Role, user.Capacity, pq.Array(foodTypeStrings(user.Capabilities)), user.CompletionMode, user.Stage, now, now,
 	).Scan(&user.ID)
 
 	if err != nil {
@@ -49,17 +54,12 @@ func (r *UserRepository) Create(ctx context.Context, user *domain.User) (*domain
 // Time Complexity: O(log n) with index on id
 func (r *UserRepository) GetByID(ctx context.Context, id int) (*domain.User, error) {
 	query := `
-		SELECT id, name, role, created_at, modified_at, deleted_at
+		SELECT ` + userColumns + `
 		FROM "user"
 		WHERE id = $1
 	`
 
-	user := &domain.User{}
-	err := r.db.QueryRowContext(ctx, query, id).Scan(
-		&user.ID, &user.Name, &user.Role,
-		&user.CreatedAt, &user.ModifiedAt, &user.DeletedAt,
-	)
-
+	user, err := scanUser(r.db.QueryRowContext(ctx, query, id).Scan)
 	if err == sql.ErrNoRows {
 		return nil, fmt.Errorf("user not found: %d", id)
 	}
@@ -74,7 +74,7 @@ func (r *UserRepository) GetByID(ctx context.Context, id int) (*domain.User, err
 // Time Complexity: O(n) with index on role for filtering
 func (r *UserRepository) GetByRole(ctx context.Context, role domain.RoleType) ([]*domain.User, error) {
 	query := `
-		SELECT id, name, role, created_at, modified_at, deleted_at
+		SELECT ` + userColumns + `
 		FROM "user"
 		WHERE role = $1 AND deleted_at IS NULL
 		ORDER BY id
@@ -88,11 +88,8 @@ func (r *UserRepository) GetByRole(ctx context.Context, role domain.RoleType) ([
 
 	var users []*domain.User
 	for rows.Next() {
-		user := &domain.User{}
-		if err := rows.Scan(
-			&user.ID, &user.Name, &user.Role,
-			&user.CreatedAt, &user.ModifiedAt, &user.DeletedAt,
-		); err != nil {
+		user, err := scanUser(rows.Scan)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, user)
@@ -105,7 +102,7 @@ func (r *UserRepository) GetByRole(ctx context.Context, role domain.RoleType) ([
 // Time Complexity: O(n) with index on role for filtering
 func (r *UserRepository) GetAllCooks(ctx context.Context, includeDeleted bool) ([]*domain.User, error) {
 	query := `
-		SELECT id, name, role, created_at, modified_at, deleted_at
+		SELECT ` + userColumns + `
 		FROM "user"
 		WHERE role = $1
 	`
@@ -123,11 +120,8 @@ func (r *UserRepository) GetAllCooks(ctx context.Context, includeDeleted bool) (
 
 	var users []*domain.User
 	for rows.Next() {
-		user := &domain.User{}
-		if err := rows.Scan(
-			&user.ID, &user.Name, &user.Role,
-			&user.CreatedAt, &user.ModifiedAt, &user.DeletedAt,
-		); err != nil {
+		user, err := scanUser(rows.Scan)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan user: %w", err)
 		}
 		users = append(users, user)
@@ -141,14 +135,16 @@ func (r *UserRepository) GetAllCooks(ctx context.Context, includeDeleted bool) (
 func (r *UserRepository) Update(ctx context.Context, user *domain.User) error {
 	query := `
 		UPDATE "user"
-		SET name = $1, role = $2, modified_at = $3, deleted_at = $4
-		WHERE id = $5
+		SET name = $1, role = $2, capacity = $3, capabilities = $4, completion_mode = $5, stage = $6,
+			modified_at = $7, deleted_at = $8
+		WHERE id = $9
 	`
 
 	user.ModifiedAt = time.Now()
 	result, err := r.db.ExecContext(
 		ctx, query,
-		user.Name, user.Role, user.ModifiedAt, user.DeletedAt, user.ID,
+		user.Name, user.Role, user.Capacity, pq.Array(foodTypeStrings(user.Capabilities)), user.CompletionMode, user.Stage,
+		user.ModifiedAt, user.DeletedAt, user.ID,
 	)
 
 	if err != nil {
@@ -220,3 +216,29 @@ func (r *UserRepository) Reinstate(ctx context.Context, id int) error {
 
 	return nil
 }
+
+// scanUser scans a row selected with userColumns (scan is row.Scan or rows.Scan)
+func scanUser(scan func(dest ...any) error) (*domain.User, error) {
+	user := &domain.User{}
+	var capabilities pq.StringArray
+	if err := scan(
+		&user.ID, &user.Name, &user.Role, &user.Capacity, &capabilities, &user.CompletionMode, &user.Stage,
+		&user.CreatedAt, &user.ModifiedAt, &user.DeletedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, foodType := range capabilities {
+		user.Capabilities = append(user.Capabilities, domain.FoodType(foodType))
+	}
+	return user, nil
+}
+
+// foodTypeStrings converts food types to plain strings for TEXT[] parameters
+func foodTypeStrings(types []domain.FoodType) []string {
+	result := make([]string, len(types))
+	for i, foodType := range types {
+		result[i] = string(foodType)
+	}
+	return result
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/logger"
+)
+
+// Autoscaler defines the interface for sizing the cook pool to the queue backlog
+// Following Interface Segregation Principle: scaling kept apart from manual cook management
+type Autoscaler interface {
+	// Start checks the queue every interval until ctx is done or Stop is called (no-op when disabled)
+	Start(ctx context.Context)
+
+	// Stop ends the checks and waits for a running one to finish
+	Stop()
+
+	// Status reports the bounds, what the last check observed and the recent scaling decisions
+	Status() AutoscalerStatus
+}
+
+// ScaleDownAction is how the autoscaler takes an idle cook off the line
+type ScaleDownAction string
+
+const (
+	ScaleDownPause  ScaleDownAction = "pause"  // Pause the cook (resumed first when scaling up again)
+	ScaleDownRemove ScaleDownAction = "remove" // Soft delete the cook (reinstated when scaling up again)
+)
+
+// ScalingAction is what a scaling decision did to a cook
+type ScalingAction string
+
+const (
+	ScalingActionCreate    ScalingAction = "create"
+	ScalingActionResume    ScalingAction = "resume"
+	ScalingActionReinstate ScalingAction = "reinstate"
+	ScalingActionPause     ScalingAction = "pause"
+	ScalingActionRemove    ScalingAction = "remove"
+)
+
+// maxScalingDecisions bounds the decision history kept for the status endpoint
+const maxScalingDecisions = 50
+
+// AutoscalerConfig bounds and paces the autoscaler (MaxCooks 0 disables it)
+type AutoscalerConfig struct {
+	MinCooks       int             // Working cooks never scaled below this
+	MaxCooks       int             // Working cooks never scaled above this (0 = autoscaler disabled)
+	BacklogPerCook int             // Queued orders per working cook above which a cook is added
+	TargetDrain    time.Duration   // A backlog the recent throughput clears within this gets no cook added (0 = backlog only)
+	Interval       time.Duration   // Time between checks
+	UpCooldown     time.Duration   // Minimum time between two scale-ups
+	DownCooldown   time.Duration   // Minimum time after any scaling decision before scaling down
+	DownAction     ScaleDownAction // How idle cooks are taken off the line
+	IntakeStage    string          // Pipeline stage served from the intake queue (empty = no pipeline)
+}
+
+// ScalingDecision records one change to the cook pool and what triggered it
+type ScalingDecision struct {
+	At           time.Time
+	Action       ScalingAction
+	CookID       int
+	CookName     string
+	Reason       string
+	QueueSize    int     // Orders waiting when the decision was made
+	WorkingCooks int     // Working cooks before the decision
+	Throughput   float64 // Orders completed per minute over the last interval
+}
+
+// AutoscalerStatus is the autoscaler's configuration, last observation and recent decisions
+type AutoscalerStatus struct {
+	Enabled      bool
+	Config       AutoscalerConfig
+	CheckedAt    time.Time // Zero until the first check
+	QueueSize    int
+	WorkingCooks int
+	Throughput   float64           // Orders completed per minute over the last interval
+	Decisions    []ScalingDecision // Most recent first
+}
+
+// autoscaler implements Autoscaler on top of the cook and order services
+// Following Single Responsibility Principle: only decides when to add or take away cooks
+// Dependency Injection: all dependencies injected via constructor
+type autoscaler struct {
+	cookService  CookService
+	orderService OrderService
+	logger       logger.Logger
+	config       AutoscalerConfig
+
+	mu            sync.Mutex        // Protects everything below
+	status        AutoscalerStatus  // Last observation (decisions kept most recent first)
+	lastCompleted int               // Completed orders at the last check (throughput baseline)
+	lastScaleUp   time.Time         // When a cook was last added
+	lastDecision  time.Time         // When the pool last changed either way
+	scaledDown    map[int]time.Time // Cooks this autoscaler took off the line, brought back first
+
+	stopChan chan struct{}  // Signal to stop checking
+	wg       sync.WaitGroup // Wait for the check loop to finish
+}
+
+// NewAutoscaler creates a new autoscaler
+// Following Dependency Injection pattern
+func NewAutoscaler(cookService CookService, orderService OrderService, log logger.Logger, config AutoscalerConfig) Autoscaler {
+	if config.BacklogPerCook < 1 {
+		config.BacklogPerCook = 1
+	}
+	if config.DownAction == "" {
+		config.DownAction = ScaleDownPause
+	}
+
+	return &autoscaler{
+		cookService:  cookService,
+		orderService: orderService,
+		logger:       log,
+		config:       config,
+		status:       AutoscalerStatus{Enabled: config.MaxCooks > 0, Config: config},
+		scaledDown:   make(map[int]time.Time),
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start checks the queue every interval in the background
+// Time Complexity: O(1) to start; each check is O(c) where c is the number of cooks
+func (a *autoscaler) Start(ctx context.Context) {
+	if !a.status.Enabled {
+		return
+	}
+
+	a.logger.Info("Autoscaler started: %d-%d cooks, adding one above %d queued orders per cook, checking every %v",
+		a.config.MinCooks, a.config.MaxCooks, a.config.BacklogPerCook, a.config.Interval)
+
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+
+		ticker := time.NewTicker(a.config.Interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				if err := a.check(ctx, now); err != nil {
+					a.logger.Error("Autoscaler check failed: %v", err)
+				}
+			case <-a.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the checks and waits for a running one to finish
+func (a *autoscaler) Stop() {
+	close(a.stopChan)
+	a.wg.Wait()
+	a.logger.Info("Autoscaler stopped")
+}
+
+// Status reports the last observation and recent scaling decisions
+// Time Complexity: O(d) where d is the number of decisions kept
+func (a *autoscaler) Status() AutoscalerStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	status := a.status
+	status.Decisions = append([]ScalingDecision(nil), a.status.Decisions...)
+	return status
+}
+
+// check observes the queue and cooks once and makes at most one scaling decision
+// Bounds are enforced first; otherwise a cook is added while the backlog per working cook is over
+// the threshold, not shrinking and would take the recent throughput longer than TargetDrain to clear,
+// and an idle cook is taken off the line once the queue is empty
+// Time Complexity: O(c log c) where c is the number of cooks
+func (a *autoscaler) check(ctx context.Context, now time.Time) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	all, err := a.cookService.GetAllCooks(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to get cooks: %w", err)
+	}
+	stats, err := a.orderService.GetOrderStats(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get order stats: %w", err)
+	}
+
+	// Only cook bots are scaled; staff completing orders by hand are scheduled by people
+	// With a pipeline only the intake stage is scaled, as that is the queue observed
+	cooks := make([]*domain.User, 0, len(all))
+	for _, cook := range all {
+		if !cook.CompletesManually() && (cook.Stage == "" || cook.Stage == a.config.IntakeStage) {
+			cooks = append(cooks, cook)
+		}
+	}
+
+	// Working cooks are neither removed nor paused. Cooks staff paused are on a break and still
+	// count towards the minimum; cooks staff removed are gone. Neither is brought back by the autoscaler
+	var working []*domain.User
+	onBreak := 0
+	for _, cook := range cooks {
+		if _, ours := a.scaledDown[cook.ID]; ours && !a.stillScaledDown(cook) {
+			// Resumed, reinstated or removed by staff since - no longer the autoscaler's to bring back
+			delete(a.scaledDown, cook.ID)
+		}
+
+		switch {
+		case !cook.IsDeleted() && cook.WorkerState != domain.WorkerStatePaused:
+			working = append(working, cook)
+		case !cook.IsDeleted() && a.scaledDown[cook.ID].IsZero():
+			onBreak++
+		}
+	}
+
+	// Throughput over the last interval (none before the first check)
+	throughput := 0.0
+	if !a.status.CheckedAt.IsZero() {
+		if elapsed := now.Sub(a.status.CheckedAt); elapsed > 0 {
+			throughput = float64(stats.Completed-a.lastCompleted) / elapsed.Minutes()
+		}
+	}
+
+	queueSize := a.orderService.GetStageQueueSize(a.config.IntakeStage)
+	shrinking := !a.status.CheckedAt.IsZero() && queueSize < a.status.QueueSize
+
+	a.status.CheckedAt = now
+	a.status.QueueSize = queueSize
+	a.status.WorkingCooks = len(working)
+	a.status.Throughput = throughput
+	a.lastCompleted = stats.Completed
+
+	decision := ScalingDecision{At: now, QueueSize: queueSize, WorkingCooks: len(working), Throughput: throughput}
+
+	// Time the recent throughput needs to clear the queue; nothing completed means it never clears
+	drain, draining := drainTime(queueSize, throughput)
+	keepingUp := a.config.TargetDrain > 0 && draining && drain <= a.config.TargetDrain
+
+	switch {
+	case len(working)+onBreak < a.config.MinCooks:
+		decision.Reason = fmt.Sprintf("below minimum of %d cooks", a.config.MinCooks)
+		return a.scaleUp(ctx, cooks, decision)
+
+	case len(working) > a.config.MaxCooks:
+		decision.Reason = fmt.Sprintf("above maximum of %d cooks", a.config.MaxCooks)
+		return a.scaleDown(ctx, working, decision)
+
+	case queueSize > a.config.BacklogPerCook*len(working) && !shrinking && !keepingUp &&
+		len(working) < a.config.MaxCooks && now.Sub(a.lastScaleUp) >= a.config.UpCooldown:
+		decision.Reason = fmt.Sprintf("backlog of %d orders over %d per cook and not shrinking", queueSize, a.config.BacklogPerCook)
+		if a.config.TargetDrain > 0 && draining {
+			decision.Reason += fmt.Sprintf(", clears in %v at %.1f/min (target %v)", drain.Round(time.Second), throughput, a.config.TargetDrain)
+		}
+		return a.scaleUp(ctx, cooks, decision)
+
+	case queueSize == 0 && len(working) > a.config.MinCooks && now.Sub(a.lastDecision) >= a.config.DownCooldown:
+		idle := make([]*domain.User, 0, len(working))
+		for _, cook := range working {
+			if cook.CurrentLoad == nil || *cook.CurrentLoad == 0 {
+				idle = append(idle, cook)
+			}
+		}
+		if len(idle) == 0 {
+			return nil
+		}
+		decision.Reason = "queue empty and cook idle"
+		return a.scaleDown(ctx, idle, decision)
+	}
+
+	return nil
+}
+
+// drainTime estimates how long a throughput in orders per minute takes to clear the queue
+// draining is false when nothing was completed (no estimate)
+// Time Complexity: O(1)
+func drainTime(queueSize int, throughput float64) (drain time.Duration, draining bool) {
+	if throughput <= 0 {
+		return 0, false
+	}
+	return time.Duration(float64(queueSize) / throughput * float64(time.Minute)), true
+}
+
+// scaleUp brings one cook onto the line: a cook this autoscaler took off it, most recent first, then a new one
+// Cooks paused or removed by staff are never resumed or reinstated
+// The caller holds mu
+// Time Complexity: O(c log c) where c is the number of cooks
+func (a *autoscaler) scaleUp(ctx context.Context, cooks []*domain.User, decision ScalingDecision) error {
+	// Most recently scaled-down cooks first (they were working last)
+	candidates := make([]*domain.User, 0, len(cooks))
+	for _, cook := range cooks {
+		if !a.scaledDown[cook.ID].IsZero() {
+			candidates = append(candidates, cook)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return a.scaledDown[candidates[i].ID].After(a.scaledDown[candidates[j].ID])
+	})
+
+	for _, cook := range candidates {
+		var err error
+		if cook.IsDeleted() {
+			decision.Action = ScalingActionReinstate
+			err = a.cookService.ReinstateCook(ctx, cook.ID)
+		} else {
+			decision.Action = ScalingActionResume
+			err = a.cookService.ResumeCook(ctx, cook.ID)
+		}
+		if err != nil {
+			// Changed by someone else since it was listed - try the next one
+			a.logger.Error("Autoscaler failed to %s cook %d: %v", decision.Action, cook.ID, err)
+			continue
+		}
+
+		decision.CookID, decision.CookName = cook.ID, cook.Name
+		a.record(decision, true)
+		return nil
+	}
+
+	cook, err := a.cookService.CreateCook(ctx, fmt.Sprintf("Autoscaled Cook %d", len(cooks)+1), 0, nil, domain.CompletionModeTimer, a.config.IntakeStage)
+	if err != nil {
+		return fmt.Errorf("failed to create cook: %w", err)
+	}
+
+	decision.Action = ScalingActionCreate
+	decision.CookID, decision.CookName = cook.ID, cook.Name
+	a.record(decision, true)
+	return nil
+}
+
+// scaleDown takes the most recently added of the candidate cooks off the line
+// The caller holds mu
+// Time Complexity: O(c) where c is the number of candidates
+func (a *autoscaler) scaleDown(ctx context.Context, candidates []*domain.User, decision ScalingDecision) error {
+	cook := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if candidate.ID > cook.ID {
+			cook = candidate
+		}
+	}
+
+	var err error
+	if a.config.DownAction == ScaleDownRemove {
+		decision.Action = ScalingActionRemove
+		err = a.cookService.RemoveCook(ctx, cook.ID)
+	} else {
+		decision.Action = ScalingActionPause
+		err = a.cookService.PauseCook(ctx, cook.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to %s cook %d: %w", decision.Action, cook.ID, err)
+	}
+
+	decision.CookID, decision.CookName = cook.ID, cook.Name
+	a.record(decision, false)
+	return nil
+}
+
+// stillScaledDown reports whether a cook is still off the line the way this autoscaler took it off
+// Time Complexity: O(1)
+func (a *autoscaler) stillScaledDown(cook *domain.User) bool {
+	if a.config.DownAction == ScaleDownRemove {
+		return cook.IsDeleted()
+	}
+	return !cook.IsDeleted() && cook.WorkerState == domain.WorkerStatePaused
+}
+
+// record logs a scaling decision and keeps it for the status endpoint
+// The caller holds mu
+// Time Complexity: O(d) where d is the number of decisions kept
+func (a *autoscaler) record(decision ScalingDecision, up bool) {
+	if up {
+		a.lastScaleUp = decision.At
+		delete(a.scaledDown, decision.CookID)
+	} else {
+		a.scaledDown[decision.CookID] = decision.At
+	}
+	a.lastDecision = decision.At
+
+	decisions := append([]ScalingDecision{decision}, a.status.Decisions...)
+	if len(decisions) > maxScalingDecisions {
+		decisions = decisions[:maxScalingDecisions]
+	}
+	a.status.Decisions = decisions
+
+	a.logger.Info("AUTOSCALER %s cook %s (ID: %d) - Reason: %s - Queue size: %d - Working cooks: %d - Throughput: %.1f orders/min",
+		decision.Action, decision.CookName, decision.CookID, decision.Reason, decision.QueueSize, decision.WorkingCooks, decision.Throughput)
+}
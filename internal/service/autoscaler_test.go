@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/logger"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupAutoscalerTest creates an autoscaler over services without running workers (queued orders stay queued)
+func setupAutoscalerTest(t *testing.T, config AutoscalerConfig) (*autoscaler, CookService, OrderService, *domain.User) {
+	cookService, orderService, userRepo, _, _ := setupCookServiceTest(t, time.Hour)
+
+	customer, err := userRepo.Create(context.Background(), &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	scaler := NewAutoscaler(cookService, orderService, logger.NewNoOpLogger(), config).(*autoscaler)
+	return scaler, cookService, orderService, customer
+}
+
+// TestAutoscalerEnforcesMinimum tests that cooks are created until the minimum is working
+func TestAutoscalerEnforcesMinimum(t *testing.T) {
+	ctx := context.Background()
+	scaler, cookService, _, _ := setupAutoscalerTest(t, AutoscalerConfig{MinCooks: 1, MaxCooks: 3, BacklogPerCook: 2})
+
+	require.NoError(t, scaler.check(ctx, time.Now()))
+
+	cooks, err := cookService.GetAllCooks(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, cooks, 1)
+
+	status := scaler.Status()
+	require.Len(t, status.Decisions, 1)
+	assert.Equal(t, ScalingActionCreate, status.Decisions[0].Action)
+	assert.Equal(t, cooks[0].ID, status.Decisions[0].CookID)
+}
+
+// TestAutoscalerScalesUpOnBacklogWithinBounds tests scale-up on backlog, the up cooldown and the maximum
+func TestAutoscalerScalesUpOnBacklogWithinBounds(t *testing.T) {
+	ctx := context.Background()
+	scaler, cookService, orderService, customer := setupAutoscalerTest(t, AutoscalerConfig{
+		MinCooks: 1, MaxCooks: 2, BacklogPerCook: 2, UpCooldown: time.Minute,
+	})
+
+	_, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+
+	start := time.Now()
+	require.NoError(t, scaler.check(ctx, start))
+	assert.Len(t, scaler.Status().Decisions, 1, "Three orders over a backlog of two per cook should add a cook")
+
+	// Six orders for two cooks is still over the threshold, but the pool is at its maximum
+	for i := 0; i < 3; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+	require.NoError(t, scaler.check(ctx, start.Add(2*time.Minute)))
+
+	status := scaler.Status()
+	assert.Len(t, status.Decisions, 1, "Pool at its maximum should not grow")
+	assert.Equal(t, 2, status.WorkingCooks)
+	assert.Equal(t, 6, status.QueueSize)
+}
+
+// TestAutoscalerRespectsUpCooldown tests that a second scale-up waits for the cooldown
+func TestAutoscalerRespectsUpCooldown(t *testing.T) {
+	ctx := context.Background()
+	scaler, cookService, orderService, customer := setupAutoscalerTest(t, AutoscalerConfig{
+		MinCooks: 1, MaxCooks: 5, BacklogPerCook: 1, UpCooldown: time.Minute,
+	})
+
+	_, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+
+	start := time.Now()
+	require.NoError(t, scaler.check(ctx, start))
+	require.NoError(t, scaler.check(ctx, start.Add(30*time.Second)))
+	assert.Len(t, scaler.Status().Decisions, 1, "No second cook within the cooldown")
+
+	require.NoError(t, scaler.check(ctx, start.Add(61*time.Second)))
+	assert.Len(t, scaler.Status().Decisions, 2, "Backlog still over the threshold after the cooldown")
+}
+
+// TestAutoscalerPausesIdleCookAndResumesItFirst tests scale-down by pause and that scale-up brings the same cook back
+func TestAutoscalerPausesIdleCookAndResumesItFirst(t *testing.T) {
+	ctx := context.Background()
+	scaler, cookService, orderService, customer := setupAutoscalerTest(t, AutoscalerConfig{
+		MinCooks: 1, MaxCooks: 3, BacklogPerCook: 1, DownAction: ScaleDownPause,
+	})
+
+	_, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	extra, err := cookService.CreateCook(ctx, "Cook Bot 2", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, scaler.check(ctx, start))
+
+	paused, err := cookService.GetCook(ctx, extra.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStatePaused, paused.WorkerState, "Newest idle cook should be paused while the queue is empty")
+
+	for i := 0; i < 2; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+	require.NoError(t, scaler.check(ctx, start.Add(time.Second)))
+
+	status := scaler.Status()
+	require.Len(t, status.Decisions, 2)
+	assert.Equal(t, ScalingActionResume, status.Decisions[0].Action, "Paused cook should be brought back before creating one")
+	assert.Equal(t, extra.ID, status.Decisions[0].CookID)
+	assert.Equal(t, ScalingActionPause, status.Decisions[1].Action)
+
+	cooks, err := cookService.GetAllCooks(ctx, true)
+	require.NoError(t, err)
+	assert.Len(t, cooks, 2, "No cook should have been created")
+}
+
+// TestAutoscalerRemovesIdleCookAndReinstatesIt tests scale-down by removal and reinstatement on scale-up
+func TestAutoscalerRemovesIdleCookAndReinstatesIt(t *testing.T) {
+	ctx := context.Background()
+	scaler, cookService, orderService, customer := setupAutoscalerTest(t, AutoscalerConfig{
+		MinCooks: 0, MaxCooks: 1, BacklogPerCook: 1, DownCooldown: time.Minute, DownAction: ScaleDownRemove,
+	})
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, scaler.check(ctx, start))
+
+	removed, err := cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.True(t, removed.IsDeleted(), "Idle cook above the minimum should be removed")
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	require.NoError(t, scaler.check(ctx, start.Add(time.Second)))
+
+	status := scaler.Status()
+	require.Len(t, status.Decisions, 2)
+	assert.Equal(t, ScalingActionReinstate, status.Decisions[0].Action)
+
+	reinstated, err := cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.False(t, reinstated.IsDeleted())
+}
+
+// TestAutoscalerLeavesStaffManagedCooksAlone tests that cooks paused or removed by staff are never brought back
+func TestAutoscalerLeavesStaffManagedCooksAlone(t *testing.T) {
+	ctx := context.Background()
+	scaler, cookService, orderService, customer := setupAutoscalerTest(t, AutoscalerConfig{
+		MinCooks: 1, MaxCooks: 3, BacklogPerCook: 1, DownAction: ScaleDownPause,
+	})
+
+	onBreak, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	removed, err := cookService.CreateCook(ctx, "Cook Bot 2", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	require.NoError(t, cookService.PauseCook(ctx, onBreak.ID))
+	require.NoError(t, cookService.RemoveCook(ctx, removed.ID))
+
+	start := time.Now()
+	require.NoError(t, scaler.check(ctx, start))
+	assert.Empty(t, scaler.Status().Decisions, "A cook on a break should count towards the minimum")
+
+	for i := 0; i < 2; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+	require.NoError(t, scaler.check(ctx, start.Add(time.Second)))
+
+	status := scaler.Status()
+	require.Len(t, status.Decisions, 1)
+	assert.Equal(t, ScalingActionCreate, status.Decisions[0].Action, "Staff-managed cooks should not be resumed or reinstated")
+
+	paused, err := cookService.GetCook(ctx, onBreak.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStatePaused, paused.WorkerState)
+	gone, err := cookService.GetCook(ctx, removed.ID)
+	require.NoError(t, err)
+	assert.True(t, gone.IsDeleted())
+}
+
+// TestAutoscalerScalesUpOnlyWhenThroughputFallsBehind tests that the same backlog adds a cook at low throughput only
+func TestAutoscalerScalesUpOnlyWhenThroughputFallsBehind(t *testing.T) {
+	tests := []struct {
+		name      string
+		completed int // Orders completed in the minute between the checks
+		scaleUp   bool
+	}{
+		{"clears within target", 8, false},
+		{"falls behind target", 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			cookService, orderService, userRepo, orderRepo, _ := setupCookServiceTest(t, time.Hour)
+			scaler := NewAutoscaler(cookService, orderService, logger.NewNoOpLogger(), AutoscalerConfig{
+				MinCooks: 1, MaxCooks: 3, BacklogPerCook: 1, TargetDrain: time.Minute,
+			}).(*autoscaler)
+
+			customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+			require.NoError(t, err)
+			_, err = cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+			require.NoError(t, err)
+
+			// Baseline check with an empty queue
+			start := time.Now()
+			require.NoError(t, scaler.check(ctx, start))
+
+			for i := 0; i < tt.completed; i++ {
+				_, err := orderRepo.Create(ctx, &domain.Order{Status: domain.OrderStatusComplete, OrderedBy: customer.ID}, nil)
+				require.NoError(t, err)
+			}
+			for i := 0; i < 4; i++ {
+				_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+				require.NoError(t, err)
+			}
+			require.NoError(t, scaler.check(ctx, start.Add(time.Minute)))
+
+			status := scaler.Status()
+			assert.Equal(t, 4, status.QueueSize)
+			assert.InDelta(t, float64(tt.completed), status.Throughput, 0.001)
+			if tt.scaleUp {
+				require.Len(t, status.Decisions, 1, "Four orders at one per minute take longer than the target")
+				assert.Equal(t, ScalingActionCreate, status.Decisions[0].Action)
+			} else {
+				assert.Empty(t, status.Decisions, "Four orders at eight per minute clear within the target")
+			}
+		})
+	}
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"mcmocknald-order-kiosk/internal/domain"
@@ -14,29 +16,63 @@ import (
 // CookService defines the interface for cook bot operations
 // Following Interface Segregation Principle: focused interface
 type CookService interface {
-	// CreateCook creates a new cook bot
-	CreateCook(ctx context.Context, name string) (*domain.User, error)
-
-	// RemoveCook soft deletes a cook bot and returns their order to queue
+	// CreateCook creates a new cook bot serving up to capacity orders at once (0 = service default)
+	// capabilities lists the food types the cook can prepare (empty = every type)
+	// mode is how its orders are completed (empty = timer); manual cooks get no worker and take orders with AcceptOrder
+	// stage is the pipeline stage the cook works (empty = first stage); ErrUnknownStage if not configured
+	// Once the worker pool is running a new timer cook's worker starts right away
+	CreateCook(ctx context.Context, name string, capacity int, capabilities []domain.FoodType, mode domain.CompletionMode, stage string) (*domain.User, error)
+
+	// RemoveCook soft deletes a cook bot, stops its worker and returns their order to queue
 	RemoveCook(ctx context.Context, cookID int) error
 
-	// ReinstateCook reinstates a soft-deleted cook bot
+	// ReinstateCook reinstates a soft-deleted cook bot (restarting its worker once the pool is running)
 	ReinstateCook(ctx context.Context, cookID int) error
 
+	// PauseCook stops a cook taking new orders; orders it already holds are finished
+	PauseCook(ctx context.Context, cookID int) error
+
+	// ResumeCook lets a paused cook take orders again
+	ResumeCook(ctx context.Context, cookID int) error
+
 	// GetCook retrieves a cook by ID
 	GetCook(ctx context.Context, cookID int) (*domain.User, error)
 
-	// GetAllCooks retrieves all cooks
+	// GetAllCooks retrieves all cooks with their capacity, current load and worker state
 	GetAllCooks(ctx context.Context, includeDeleted bool) ([]*domain.User, error)
 
-	// AcceptOrder assigns an order from the queue to a cook and processes it
+	// IsServing reports whether a cook of this process holds an order: cooking it, recording a failed
+	// attempt or waiting for a manual cook to complete it (paused cooks still finish what they hold)
+	IsServing(orderID int) bool
+
+	// AcceptOrder assigns the next order the cook can prepare from its stage's queue and processes it
+	// Returns ErrCookAtCapacity when the cook has no free slot and ErrCookPaused while paused
 	AcceptOrder(ctx context.Context, cookID int) (*domain.Order, error)
 
-	// StartWorkerPool starts the worker pool with N cook bots
+	// CompleteOrder completes an order a manual cook is serving, freeing its slot
+	// Returns ErrCookNotManual for timer cooks and ErrOrderNotAssignedToCook for another cook's order
+	CompleteOrder(ctx context.Context, cookID, orderID int) error
+
+	// StartWorkerPool starts a worker for every active cook, creating cook bots until at least numCooks exist
+	// (numCooks per stage with a pipeline)
+	// Cooks created or reinstated afterwards get their worker automatically
 	StartWorkerPool(ctx context.Context, numCooks int) error
 
-	// StopWorkerPool stops all workers gracefully
+	// StopWorkerPool stops all workers gracefully (orders being cooked carry on, see DrainOrders)
 	StopWorkerPool()
+
+	// DrainOrders waits for orders being cooked to complete until ctx is done
+	// Orders still cooking then are returned to PENDING at the front of the queue; returns how many
+	DrainOrders(ctx context.Context) int
+}
+
+// CookServiceConfig tunes how cooks take and cook orders
+type CookServiceConfig struct {
+	ServingDuration time.Duration // Prep time of items without their own
+	DefaultCapacity int           // Concurrent orders for cooks without their own capacity (minimum 1)
+	ItemParallelism int           // Items of an order a cook prepares at once (0 = all of them)
+	Pipeline        *Pipeline     // Stages with their own queues and cooks (nil = one stage over the order queue)
+	Failures        FailureConfig // Simulated cook bot failures (zero value = never)
 }
 
 // cookService implements cook bot business logic
@@ -45,50 +81,177 @@ type CookService interface {
 type cookService struct {
 	userRepo        domain.UserRepository
 	orderRepo       domain.OrderRepository
-	orderQueue      queue.OrderQueue
+	pipeline        *Pipeline // Stage queues (a single stage over the order queue without a pipeline)
 	logger          logger.Logger
-	servingDuration time.Duration
+	defaultCapacity int           // Concurrent orders for cooks without their own capacity
+	prep            prepTimer     // Order cook time from its items
+	failures        FailureConfig // Simulated cooking failures and retries
+
+	// Per-cook capacity slots (shared by workers and manual accepts)
+	slots   map[int]*cookSlots // Map of cook ID to its in-flight orders
+	slotsMu sync.Mutex         // Protects slots map
 
 	// Worker pool management
-	workers   map[int]*cookWorker // Map of cook ID to worker
-	workersMu sync.RWMutex        // Protects workers map
-	stopChan  chan struct{}       // Signal to stop all workers
-	wg        sync.WaitGroup      // Wait for all workers to finish
+	workers   map[int]*cookWorker   // Map of cook ID to worker
+	paused    map[int]chan struct{} // Paused cook IDs; the channel is closed on resume
+	poolCtx   context.Context       // Worker context while the pool runs (nil = workers not started)
+	workersMu sync.RWMutex          // Protects workers and paused maps
+	stopChan  chan struct{}         // Signal to stop all workers
+	wg        sync.WaitGroup        // Wait for all workers to finish
+
+	// Orders served by manual cooks, waiting for CompleteOrder
+	manual   map[int]*manualOrder // Map of order ID to its completion handshake
+	manualMu sync.Mutex           // Protects manual map
+
+	// Orders held by a cook of this process, from assignment until processing ends (reaper liveness)
+	held   map[int]*heldOrder // Map of order ID to its current hold
+	heldMu sync.Mutex         // Protects held map
+
+	// In-flight orders (tracked so shutdown can drain them)
+	inflight   sync.WaitGroup // processOrder goroutines still cooking
+	drainStart chan struct{}  // Closed when the drain starts: manual orders go back to PENDING (nobody can complete them)
+	drainAbort chan struct{}  // Closed at the drain deadline: orders still cooking go back to PENDING
+	startOnce  sync.Once      // Guards closing drainStart
+	drainOnce  sync.Once      // Guards closing drainAbort
+	drained    atomic.Int64   // Orders returned to PENDING by the drain
 }
 
+// workerRetryInterval is how long a worker backs off after an unexpected accept failure
+const workerRetryInterval = time.Second
+
 // cookWorker represents a worker goroutine processing orders
 type cookWorker struct {
 	cookID    int
 	stopChan  chan struct{}
 	isRunning bool
+	interrupt context.CancelFunc // Cuts short the current wait for an order (on pause)
+}
+
+// manualOrder hands a manual cook's completion to the processOrder goroutine serving the order
+type manualOrder struct {
+	cookID    int
+	completed chan struct{} // Closed by CompleteOrder
+	result    chan error    // Receives the outcome from processOrder (buffered, sent once)
+}
+
+// heldOrder is one cook's hold on an order; an order handed back and taken again gets a new hold,
+// so the first holder finishing late cannot clear the second
+type heldOrder struct {
+	cookID int
+}
+
+// cookSlots bounds how many orders one cook serves at once
+// Following Semaphore pattern: a buffered channel holds one token per in-flight order
+type cookSlots struct {
+	tokens  chan struct{}
+	removed chan struct{} // Closed when the cook is removed (orders in hand are abandoned)
+}
+
+// acquire blocks until a slot is free or ctx is done
+// Time Complexity: O(1)
+func (c *cookSlots) acquire(ctx context.Context) error {
+	select {
+	case c.tokens <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// tryAcquire takes a slot without waiting, reporting whether one was free
+// Time Complexity: O(1)
+func (c *cookSlots) tryAcquire() bool {
+	select {
+	case c.tokens <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// release frees a slot taken by acquire or tryAcquire
+// Time Complexity: O(1)
+func (c *cookSlots) release() {
+	<-c.tokens
+}
+
+// load returns the number of slots in use
+// Time Complexity: O(1)
+func (c *cookSlots) load() int {
+	return len(c.tokens)
 }
 
 // NewCookService creates a new cook service
+// orderQueue is the queue cooks take orders from (the first stage's queue with a pipeline)
 // Following Dependency Injection pattern
 func NewCookService(
 	userRepo domain.UserRepository,
 	orderRepo domain.OrderRepository,
 	orderQueue queue.OrderQueue,
 	log logger.Logger,
-	servingDuration time.Duration,
+	config CookServiceConfig,
 ) CookService {
+	if config.DefaultCapacity < 1 {
+		config.DefaultCapacity = 1
+	}
+	if config.Failures.MaxAttempts < 1 {
+		config.Failures.MaxAttempts = 1
+	}
+	pipeline := config.Pipeline
+	if pipeline == nil {
+		pipeline = singleStagePipeline(orderQueue)
+	}
+
 	return &cookService{
 		userRepo:        userRepo,
 		orderRepo:       orderRepo,
-		orderQueue:      orderQueue,
+		pipeline:        pipeline,
 		logger:          log,
-		servingDuration: servingDuration,
+		defaultCapacity: config.DefaultCapacity,
+		prep:            prepTimer{defaultPrepTime: config.ServingDuration, parallelism: config.ItemParallelism},
+		failures:        config.Failures,
+		slots:           make(map[int]*cookSlots),
 		workers:         make(map[int]*cookWorker),
+		paused:          make(map[int]chan struct{}),
+		manual:          make(map[int]*manualOrder),
+		held:            make(map[int]*heldOrder),
 		stopChan:        make(chan struct{}),
+		drainStart:      make(chan struct{}),
+		drainAbort:      make(chan struct{}),
 	}
 }
 
 // CreateCook creates a new cook bot
 // Time Complexity: O(1) for in-memory, O(log n) for database
-func (s *cookService) CreateCook(ctx context.Context, name string) (*domain.User, error) {
+func (s *cookService) CreateCook(ctx context.Context, name string, capacity int, capabilities []domain.FoodType, mode domain.CompletionMode, stage string) (*domain.User, error) {
+	if capacity < 0 {
+		return nil, ErrInvalidCookCapacity
+	}
+
+	if mode == "" {
+		mode = domain.CompletionModeTimer
+	}
+	if mode != domain.CompletionModeTimer && mode != domain.CompletionModeManual {
+		return nil, fmt.Errorf("%w: %s (must be 'timer' or 'manual')", ErrInvalidCompletionMode, mode)
+	}
+
+	for _, foodType := range capabilities {
+		if !isValidFoodType(foodType) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCookCapability, foodType)
+		}
+	}
+
+	if !s.pipeline.Has(stage) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownStage, stage)
+	}
+
 	cook := &domain.User{
-		Name: name,
-		Role: domain.RoleCook,
+		Name:           name,
+		Role:           domain.RoleCook,
+		Capacity:       capacity,
+		Capabilities:   capabilities,
+		CompletionMode: mode,
+		Stage:          stage,
 	}
 
 	createdCook, err := s.userRepo.Create(ctx, cook)
@@ -97,8 +260,12 @@ func (s *cookService) CreateCook(ctx context.Context, name string) (*domain.User
 		return nil, fmt.Errorf("failed to create cook: %w", err)
 	}
 
-	s.logger.Info("Cook bot created: %s (ID: %d)", createdCook.Name, createdCook.ID)
-	return createdCook, nil
+	s.logger.Info("Cook bot created: %s (ID: %d, capacity: %d, capabilities: %s, completion: %s, stage: %s)",
+		createdCook.Name, createdCook.ID, createdCook.EffectiveCapacity(s.defaultCapacity), describeCapabilities(createdCook.Capabilities), mode,
+		describeStage(s.pipeline.resolve(stage)))
+
+	s.startPoolWorker(createdCook)
+	return s.withLoad(createdCook), nil
 }
 
 // RemoveCook soft deletes a cook bot and returns their order to queue
@@ -121,8 +288,12 @@ func (s *cookService) RemoveCook(ctx context.Context, cookID int) error {
 		return fmt.Errorf("cook is already deleted")
 	}
 
-	// Stop worker if running
+	// Stop worker if running (a removed cook is no longer paused either)
 	s.stopWorker(cookID)
+	s.clearPause(cookID)
+
+	// Stop cooking the orders in hand - they go back to the queue below
+	s.dropSlots(cookID)
 
 	// Get orders assigned to this cook
 	orders, err := s.orderRepo.GetByCookID(ctx, cookID)
@@ -146,8 +317,13 @@ func (s *cookService) RemoveCook(ctx context.Context, cookID int) error {
 				continue
 			}
 
-			// Re-enqueue at front of queue (#1 position)
-			if err := s.orderQueue.EnqueueAtFront(order); err != nil {
+			// Foods route the order to a cook that can prepare it
+			if err := loadOrderFoods(ctx, s.orderRepo, order); err != nil {
+				s.logger.Error("Failed to get foods for order %d: %v", order.ID, err)
+			}
+
+			// Re-enqueue at front of its stage's queue (#1 position)
+			if err := s.pipeline.Queue(order.Stage).EnqueueAtFront(order); err != nil {
 				s.logger.Error("Failed to re-enqueue order %d: %v", order.ID, err)
 				continue
 			}
@@ -193,9 +369,95 @@ func (s *cookService) ReinstateCook(ctx context.Context, cookID int) error {
 	}
 
 	s.logger.Info("Cook %s (ID: %d) reinstated", cook.Name, cookID)
+
+	s.startPoolWorker(cook)
+	return nil
+}
+
+// PauseCook stops a cook taking new orders until ResumeCook
+// Orders the cook already holds are finished; a worker waiting for an order is woken so it takes none
+// Pause state lives in this process (other instances sharing a postgres queue keep their own)
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) PauseCook(ctx context.Context, cookID int) error {
+	cook, err := s.getActiveCook(ctx, cookID)
+	if err != nil {
+		s.logger.Error("Failed to pause cook %d: %v", cookID, err)
+		return err
+	}
+
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+
+	if _, paused := s.paused[cookID]; paused {
+		return ErrCookPaused
+	}
+	s.paused[cookID] = make(chan struct{})
+
+	if worker, exists := s.workers[cookID]; exists && worker.interrupt != nil {
+		worker.interrupt()
+	}
+
+	s.logger.Info("Cook %s (ID: %d) PAUSED - Finishing current orders", cook.Name, cookID)
 	return nil
 }
 
+// ResumeCook lets a paused cook take orders again
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) ResumeCook(ctx context.Context, cookID int) error {
+	cook, err := s.getActiveCook(ctx, cookID)
+	if err != nil {
+		s.logger.Error("Failed to resume cook %d: %v", cookID, err)
+		return err
+	}
+
+	if !s.clearPause(cookID) {
+		return ErrCookNotPaused
+	}
+
+	s.logger.Info("Cook %s (ID: %d) RESUMED", cook.Name, cookID)
+	return nil
+}
+
+// clearPause resumes a cook's parked worker, reporting whether the cook was paused
+// Time Complexity: O(1)
+func (s *cookService) clearPause(cookID int) bool {
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+
+	resumed, paused := s.paused[cookID]
+	if !paused {
+		return false
+	}
+	close(resumed)
+	delete(s.paused, cookID)
+	return true
+}
+
+// isPaused reports whether a cook is paused
+// Time Complexity: O(1)
+func (s *cookService) isPaused(cookID int) bool {
+	s.workersMu.RLock()
+	defer s.workersMu.RUnlock()
+
+	_, paused := s.paused[cookID]
+	return paused
+}
+
+// workerState reports what a cook's worker is doing
+// Time Complexity: O(1)
+func (s *cookService) workerState(cookID int) domain.WorkerState {
+	s.workersMu.RLock()
+	defer s.workersMu.RUnlock()
+
+	if _, paused := s.paused[cookID]; paused {
+		return domain.WorkerStatePaused
+	}
+	if worker, exists := s.workers[cookID]; exists && worker.isRunning {
+		return domain.WorkerStateRunning
+	}
+	return domain.WorkerStateStopped
+}
+
 // GetCook retrieves a cook by ID
 // Time Complexity: O(1) for in-memory, O(log n) for database
 func (s *cookService) GetCook(ctx context.Context, cookID int) (*domain.User, error) {
@@ -209,10 +471,10 @@ func (s *cookService) GetCook(ctx context.Context, cookID int) (*domain.User, er
 		return nil, fmt.Errorf("user is not a cook")
 	}
 
-	return cook, nil
+	return s.withLoad(cook), nil
 }
 
-// GetAllCooks retrieves all cooks
+// GetAllCooks retrieves all cooks with their capacity, current load and worker state
 // Time Complexity: O(n) - must scan all users
 func (s *cookService) GetAllCooks(ctx context.Context, includeDeleted bool) ([]*domain.User, error) {
 	cooks, err := s.userRepo.GetAllCooks(ctx, includeDeleted)
@@ -221,80 +483,336 @@ func (s *cookService) GetAllCooks(ctx context.Context, includeDeleted bool) ([]*
 		return nil, err
 	}
 
-	return cooks, nil
+	result := make([]*domain.User, 0, len(cooks))
+	for _, cook := range cooks {
+		result = append(result, s.withLoad(cook))
+	}
+
+	return result, nil
+}
+
+// withLoad returns a copy of cook reporting its effective capacity, current load and worker state
+// The stored user is never mutated (in-memory repositories hand out shared pointers)
+// Time Complexity: O(1)
+func (s *cookService) withLoad(cook *domain.User) *domain.User {
+	view := *cook
+	view.Capacity = cook.EffectiveCapacity(s.defaultCapacity)
+
+	load := 0
+	s.slotsMu.Lock()
+	if slots, exists := s.slots[cook.ID]; exists {
+		load = slots.load()
+	}
+	s.slotsMu.Unlock()
+	view.CurrentLoad = &load
+	view.WorkerState = s.workerState(cook.ID)
+
+	return &view
+}
+
+// describeCapabilities formats a cook's food types for logs
+// Time Complexity: O(t) where t is the number of types
+func describeCapabilities(capabilities []domain.FoodType) string {
+	if len(capabilities) == 0 {
+		return "all"
+	}
+	return strings.Join(queue.Capabilities(capabilities).Strings(), ", ")
+}
+
+// describeStage formats a stage name for logging (no pipeline = a single unnamed stage)
+// Time Complexity: O(1)
+func describeStage(stage string) string {
+	if stage == "" {
+		return "all"
+	}
+	return stage
+}
+
+// slotsFor returns the capacity slots of a cook, creating them on first use
+// Time Complexity: O(1)
+func (s *cookService) slotsFor(cook *domain.User) *cookSlots {
+	s.slotsMu.Lock()
+	defer s.slotsMu.Unlock()
+
+	slots, exists := s.slots[cook.ID]
+	if !exists {
+		slots = &cookSlots{
+			tokens:  make(chan struct{}, cook.EffectiveCapacity(s.defaultCapacity)),
+			removed: make(chan struct{}),
+		}
+		s.slots[cook.ID] = slots
+	}
+	return slots
+}
+
+// dropSlots abandons a removed cook's in-flight orders and forgets its slots
+// A reinstated cook starts with every slot free
+// Time Complexity: O(1)
+func (s *cookService) dropSlots(cookID int) {
+	s.slotsMu.Lock()
+	defer s.slotsMu.Unlock()
+
+	if slots, exists := s.slots[cookID]; exists {
+		close(slots.removed)
+		delete(s.slots, cookID)
+	}
 }
 
 // AcceptOrder assigns an order from the queue to a cook and processes it
 // Time Complexity: O(1) for queue dequeue + O(1) for order update
 func (s *cookService) AcceptOrder(ctx context.Context, cookID int) (*domain.Order, error) {
 	// Validate cook exists and is active
-	cook, err := s.userRepo.GetByID(ctx, cookID)
+	cook, err := s.getActiveCook(ctx, cookID)
 	if err != nil {
-		return nil, fmt.Errorf("cook not found: %w", err)
+		return nil, err
 	}
 
-	if !cook.IsCook() {
-		return nil, fmt.Errorf("user is not a cook")
+	if s.isPaused(cookID) {
+		return nil, ErrCookPaused
 	}
 
-	if cook.IsDeleted() {
-		return nil, fmt.Errorf("cook is deleted")
+	// Refuse rather than wait when every slot is taken
+	slots := s.slotsFor(cook)
+	if !slots.tryAcquire() {
+		return nil, ErrCookAtCapacity
 	}
 
-	// Dequeue order from priority queue
-	order, err := s.orderQueue.Dequeue()
+	// Dequeue the next order this cook can prepare at its stage (orders for other stations keep their place)
+	order, err := s.pipeline.Queue(cook.Stage).DequeueFor(queue.Capabilities(cook.Capabilities))
 	if err != nil {
+		slots.release()
 		if err == queue.ErrEmptyQueue {
 			return nil, fmt.Errorf("no orders in queue")
 		}
+		if err == queue.ErrNoMatchingOrder {
+			return nil, fmt.Errorf("no orders in queue this cook can prepare")
+		}
 		return nil, fmt.Errorf("failed to dequeue order: %w", err)
 	}
 
+	if err := s.startOrder(ctx, cook, order, slots); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// getActiveCook retrieves a cook and validates it can take orders
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) getActiveCook(ctx context.Context, cookID int) (*domain.User, error) {
+	cook, err := s.userRepo.GetByID(ctx, cookID)
+	if err != nil {
+		return nil, fmt.Errorf("cook not found: %w", err)
+	}
+
+	if !cook.IsCook() {
+		return nil, fmt.Errorf("user is not a cook")
+	}
+
+	if cook.IsDeleted() {
+		return nil, fmt.Errorf("cook is deleted")
+	}
+
+	return cook, nil
+}
+
+// startOrder assigns a dequeued order to a cook, marks it SERVING and starts processing
+// The caller holds one of the cook's slots; it is released when processing ends (or here on failure)
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) startOrder(ctx context.Context, cook *domain.User, order *domain.Order, slots *cookSlots) error {
+	stageQueue := s.pipeline.Queue(order.Stage)
+
+	// Held before it shows as SERVING, so the reaper never mistakes it for an orphan
+	held := s.hold(order.ID, cook.ID)
+
 	// Assign cook to order
-	if err := s.orderRepo.AssignCook(ctx, order.ID, cookID); err != nil {
-		// Return order to queue if assignment fails
-		_ = s.orderQueue.EnqueueAtFront(order)
-		return nil, fmt.Errorf("failed to assign cook: %w", err)
+	if err := s.orderRepo.AssignCook(ctx, order.ID, cook.ID); err != nil {
+		s.unhold(order.ID, held)
+		slots.release()
+		// Return order to queue if assignment fails (a shared queue hands it out already SERVING)
+		if requeueErr := s.returnToQueue(ctx, order); requeueErr != nil {
+			s.logger.Error("Failed to return order %d to queue: %v", order.ID, requeueErr)
+		}
+		return fmt.Errorf("failed to assign cook: %w", err)
 	}
 
 	// Update order status to SERVING
 	if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusServing); err != nil {
-		return nil, fmt.Errorf("failed to update order status: %w", err)
+		s.unhold(order.ID, held)
+		slots.release()
+		if requeueErr := s.returnToQueue(ctx, order); requeueErr != nil {
+			s.logger.Error("Failed to return order %d to queue: %v", order.ID, requeueErr)
+		}
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	// Cook time comes from the items (orders claimed from the shared queue arrive without them)
+	if err := loadOrderFoods(ctx, s.orderRepo, order); err != nil {
+		s.logger.Error("Failed to get foods for order %d, using default prep time: %v", order.ID, err)
+	}
+
+	// Record who handles this stage (without a pipeline the assigned cook says it all)
+	if s.pipeline.Enabled() {
+		if err := s.orderRepo.StartStage(ctx, order.ID, order.Stage, cook.ID); err != nil {
+			s.logger.Error("Failed to record %s stage start for order %d: %v", order.Stage, order.ID, err)
+		}
+	}
+
+	prepTime := s.pipeline.duration(order, s.prep)
+
+	// Manual cooks complete the order themselves (CompleteOrder); prepTime is then only an estimate
+	var waiter *manualOrder
+	if cook.CompletesManually() {
+		waiter = &manualOrder{cookID: cook.ID, completed: make(chan struct{}), result: make(chan error, 1)}
+		s.manualMu.Lock()
+		s.manual[order.ID] = waiter
+		s.manualMu.Unlock()
 	}
 
 	// Enhanced logging: Cook takes up an order
-	s.logger.Info("Cook %s (ID: %d) TOOK ORDER %d - Queue size: %d",
-		cook.Name, cookID, order.ID, s.orderQueue.Size())
+	s.logger.Info("Cook %s (ID: %d) TOOK ORDER %d - Stage: %s - Prep time: %v - Completion: %s - Load: %d/%d - Queue size: %d",
+		cook.Name, cook.ID, order.ID, describeStage(order.Stage), prepTime, cook.CompletionMode, slots.load(), cap(slots.tokens), stageQueue.Size())
 
-	// Process order in background (simulate 10s cooking time)
-	go s.processOrder(ctx, order.ID, cookID)
+	// Process order in background (cooking time derived from its items)
+	// Cooking outlives the request or worker that started it; shutdown drains it instead (DrainOrders)
+	// The hold ends with processing, however it ends
+	s.inflight.Add(1)
+	go func() {
+		defer s.unhold(order.ID, held)
+		s.processOrder(context.WithoutCancel(ctx), order, cook.ID, prepTime, slots, waiter)
+	}()
 
-	return order, nil
+	return nil
+}
+
+// hold records that a cook of this process holds an order, returning the hold
+// Time Complexity: O(1)
+func (s *cookService) hold(orderID, cookID int) *heldOrder {
+	s.heldMu.Lock()
+	defer s.heldMu.Unlock()
+	held := &heldOrder{cookID: cookID}
+	s.held[orderID] = held
+	return held
 }
 
-// processOrder simulates order processing (SERVING -> COMPLETE after servingDuration)
+// unhold ends a hold once processing ended (or never started); a newer hold on the order is kept
+// Time Complexity: O(1)
+func (s *cookService) unhold(orderID int, held *heldOrder) {
+	s.heldMu.Lock()
+	defer s.heldMu.Unlock()
+	if s.held[orderID] == held {
+		delete(s.held, orderID)
+	}
+}
+
+// IsServing reports whether a cook of this process holds an order
+// Time Complexity: O(1)
+func (s *cookService) IsServing(orderID int) bool {
+	s.heldMu.Lock()
+	defer s.heldMu.Unlock()
+	_, held := s.held[orderID]
+	return held
+}
+
+// processOrder simulates order processing (SERVING -> COMPLETE after prepTime)
+// Orders of manual cooks (waiter set) complete when the cook calls CompleteOrder instead
+// With a pipeline every stage but the last hands the order PENDING to the next stage's queue
+// Cook bots may fail an attempt (FailureConfig): the order is retried or, out of attempts, FAILED
+// An order completed after its SLA deadline is recorded as breached
+// The cook's slot is freed however processing ends
 // Time Complexity: O(1) - single order update after sleep
-func (s *cookService) processOrder(ctx context.Context, orderID, cookID int) {
+func (s *cookService) processOrder(ctx context.Context, order *domain.Order, cookID int, prepTime time.Duration, slots *cookSlots, waiter *manualOrder) {
+	defer s.inflight.Done()
+	defer slots.release()
+
+	orderID := order.ID
+	stage := order.Stage // The order belongs to the next stage's cook once handed on
+
+	// A manual order handed back below can no longer be completed
+	// Once shutdown stopped intake its cook cannot reach the complete endpoint, so it goes back right away
+	var timer <-chan time.Time
+	var completed <-chan struct{}
+	abort := s.drainAbort
+	result := ErrOrderNotServing
+	if waiter != nil {
+		completed = waiter.completed
+		abort = s.drainStart
+		defer s.forgetManual(orderID)
+		defer func() { waiter.result <- result }()
+	} else {
+		timer = time.After(prepTime)
+	}
+
 	// Record start time for processing duration calculation
 	startTime := time.Now()
 
-	// Simulate cooking time with context cancellation support
+	// Simulate cooking time (derived from the order's items) or wait for the cook; shutdown or removal can cut it short
 	select {
-	case <-time.After(s.servingDuration):
+	case <-timer:
 		// Cooking completed normally
-	case <-ctx.Done():
-		// Context cancelled - order is abandoned
+	case <-completed:
+		// Manual cook reported the order done
+	case <-abort:
+		// Shutdown - hand the order back rather than leave it SERVING
+		processingTime := time.Since(startTime)
+		if err := s.returnToQueue(ctx, order); err != nil {
+			s.logger.Error("Failed to return order %d to queue on shutdown: %v", orderID, err)
+			return
+		}
+		s.drained.Add(1)
+		s.logger.Info("Cook %d RETURNED ORDER %d to queue - Reason: Shutdown - Processing time: %v",
+			cookID, orderID, processingTime.Round(time.Millisecond))
+		return
+	case <-slots.removed:
+		// Cook removed - the order was returned to the queue for another cook
 		processingTime := time.Since(startTime)
-		s.logger.Info("Cook %d ABANDONED ORDER %d - Reason: Cancelled (%v) - Processing time: %v",
-			cookID, orderID, ctx.Err(), processingTime.Round(time.Millisecond))
+		s.logger.Info("Cook %d ABANDONED ORDER %d - Reason: Cook removed - Processing time: %v",
+			cookID, orderID, processingTime.Round(time.Millisecond))
 		return
 	}
 
+	// Simulated failure (cook bots only - staff report their own outcome): the attempt is lost
+	if waiter == nil && s.failures.attemptFails() {
+		s.failAttempt(ctx, order, cookID, time.Since(startTime))
+		return
+	}
+
+	if s.pipeline.Enabled() {
+		if err := s.orderRepo.CompleteStage(ctx, orderID, stage); err != nil {
+			s.logger.Error("Failed to record %s stage completion for order %d: %v", stage, orderID, err)
+		}
+
+		if next, ok := s.pipeline.Next(stage); ok {
+			if err := s.advanceStage(ctx, order, next.Name); err != nil {
+				s.logger.Error("Failed to move order %d to stage %s: %v", orderID, next.Name, err)
+				result = err
+				return
+			}
+			result = nil
+
+			s.logger.Info("Cook %d FINISHED STAGE %s of ORDER %d - Next stage: %s - Processing time: %v",
+				cookID, stage, orderID, next.Name, time.Since(startTime).Round(time.Millisecond))
+			return
+		}
+	}
+
+	// Record an SLA breach before completing, so stats never count a late order as on time
+	if completedAt := time.Now(); order.IsPastDeadline(completedAt) {
+		if err := s.orderRepo.MarkSLABreached(ctx, orderID); err != nil {
+			s.logger.Error("Failed to record SLA breach for order %d: %v", orderID, err)
+		} else {
+			s.logger.Info("Order %d BREACHED SLA - Completed %v after deadline",
+				orderID, completedAt.Sub(*order.Deadline).Round(time.Millisecond))
+		}
+	}
+
 	// Update order status to COMPLETE
 	if err := s.orderRepo.UpdateStatus(ctx, orderID, domain.OrderStatusComplete); err != nil {
 		s.logger.Error("Failed to complete order %d: %v", orderID, err)
+		result = fmt.Errorf("failed to complete order: %w", err)
 		return
 	}
+	result = nil
 
 	// Calculate processing time
 	processingTime := time.Since(startTime)
@@ -304,7 +822,63 @@ func (s *cookService) processOrder(ctx context.Context, orderID, cookID int) {
 		cookID, orderID, processingTime.Round(time.Millisecond))
 }
 
-// StartWorkerPool starts N cook bot workers that continuously process orders
+// failAttempt records a failed cooking attempt
+// The order goes back to the front of its stage's queue, or to FAILED once it used up its attempts
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) failAttempt(ctx context.Context, order *domain.Order, cookID int, processingTime time.Duration) {
+	attempts, err := s.orderRepo.IncrementAttempts(ctx, order.ID)
+	if err != nil {
+		// Left SERVING: the stuck-order reaper returns it to the queue
+		s.logger.Error("Failed to record failed attempt for order %d: %v", order.ID, err)
+		return
+	}
+	order.Attempts = attempts
+
+	if attempts >= s.failures.MaxAttempts {
+		if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusFailed); err != nil {
+			s.logger.Error("Failed to mark order %d FAILED: %v", order.ID, err)
+			return
+		}
+		if err := s.orderRepo.UnassignCook(ctx, order.ID); err != nil {
+			s.logger.Error("Failed to unassign cook from order %d: %v", order.ID, err)
+		}
+		s.logger.Info("Cook %d FAILED ORDER %d - Attempt %d/%d - Order FAILED, waiting to be requeued - Processing time: %v",
+			cookID, order.ID, attempts, s.failures.MaxAttempts, processingTime.Round(time.Millisecond))
+		return
+	}
+
+	if err := s.returnToQueue(ctx, order); err != nil {
+		s.logger.Error("Failed to return failed order %d to queue: %v", order.ID, err)
+		return
+	}
+	s.logger.Info("Cook %d FAILED ORDER %d - Attempt %d/%d - Returned to queue front - Processing time: %v",
+		cookID, order.ID, attempts, s.failures.MaxAttempts, processingTime.Round(time.Millisecond))
+}
+
+// advanceStage hands an order to the next pipeline stage: PENDING, unassigned, at the back of that stage's queue
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) advanceStage(ctx context.Context, order *domain.Order, next string) error {
+	// Move the stage first: once PENDING the order must be found in the right queue
+	if err := s.orderRepo.SetStage(ctx, order.ID, next); err != nil {
+		return fmt.Errorf("failed to set stage: %w", err)
+	}
+	order.Stage = next
+
+	if err := s.orderRepo.UnassignCook(ctx, order.ID); err != nil {
+		return fmt.Errorf("failed to unassign cook: %w", err)
+	}
+	if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusPending); err != nil {
+		return fmt.Errorf("failed to reset order status: %w", err)
+	}
+	if err := s.pipeline.Queue(next).Enqueue(order); err != nil {
+		return fmt.Errorf("failed to enqueue order: %w", err)
+	}
+	return nil
+}
+
+// StartWorkerPool starts cook bot workers that continuously process orders
+// Existing active cooks (e.g. persisted by a previous run) count towards numCooks; only the shortfall is created
+// ctx lives as long as the pool: workers started later by CreateCook and ReinstateCook use it too
 // Time Complexity: O(n) where n is number of cooks
 func (s *cookService) StartWorkerPool(ctx context.Context, numCooks int) error {
 	s.logger.Info("Starting worker pool with %d cook bots", numCooks)
@@ -315,21 +889,80 @@ func (s *cookService) StartWorkerPool(ctx context.Context, numCooks int) error {
 		return fmt.Errorf("failed to get cooks: %w", err)
 	}
 
-	// Start workers for each cook
+	s.workersMu.Lock()
+	s.poolCtx = ctx
+
+	// Start workers for each timer cook (manual cooks take orders with AcceptOrder), counting them per stage
+	bots := make(map[string]int)
 	for _, cook := range cooks {
-		if err := s.startWorker(ctx, cook.ID); err != nil {
+		if cook.CompletesManually() {
+			continue
+		}
+		bots[s.pipeline.resolve(cook.Stage)]++
+		if err := s.startWorkerLocked(ctx, cook.ID); err != nil {
 			s.logger.Error("Failed to start worker for cook %d: %v", cook.ID, err)
 		}
 	}
+	s.workersMu.Unlock()
+
+	// Create the missing cook bots of every stage (their workers start with them)
+	for _, stage := range s.stageNames() {
+		for i := bots[stage]; i < numCooks; i++ {
+			if _, err := s.CreateCook(ctx, cookBotName(stage, i+1), 0, nil, domain.CompletionModeTimer, stage); err != nil {
+				return fmt.Errorf("failed to create initial cook bot: %w", err)
+			}
+		}
+	}
 
 	return nil
 }
 
-// startWorker starts a worker goroutine for a specific cook
-func (s *cookService) startWorker(ctx context.Context, cookID int) error {
+// stageNames returns the stages the worker pool staffs (a single unnamed one without a pipeline)
+// Time Complexity: O(s) where s is the number of stages
+func (s *cookService) stageNames() []string {
+	if !s.pipeline.Enabled() {
+		return []string{""}
+	}
+
+	names := make([]string, 0, len(s.pipeline.Stages()))
+	for _, stage := range s.pipeline.Stages() {
+		names = append(names, stage.Name)
+	}
+	return names
+}
+
+// cookBotName names the n-th cook bot the worker pool creates for a stage
+// Time Complexity: O(1)
+func cookBotName(stage string, n int) string {
+	if stage == "" {
+		return fmt.Sprintf("Cook Bot %d", n)
+	}
+	return fmt.Sprintf("Cook Bot %d (%s)", n, stage)
+}
+
+// startPoolWorker starts a cook's worker if the worker pool is running (no-op before StartWorkerPool)
+// Manual cooks never get a worker: a person takes their orders
+// Time Complexity: O(1)
+func (s *cookService) startPoolWorker(cook *domain.User) {
+	if cook.CompletesManually() {
+		return
+	}
+
 	s.workersMu.Lock()
 	defer s.workersMu.Unlock()
 
+	if s.poolCtx == nil {
+		return
+	}
+
+	if err := s.startWorkerLocked(s.poolCtx, cook.ID); err != nil {
+		s.logger.Error("Failed to start worker for cook %d: %v", cook.ID, err)
+	}
+}
+
+// startWorkerLocked starts a worker goroutine for a specific cook
+// The caller must hold workersMu
+func (s *cookService) startWorkerLocked(ctx context.Context, cookID int) error {
 	// Check if worker already exists
 	if worker, exists := s.workers[cookID]; exists && worker.isRunning {
 		return fmt.Errorf("worker already running for cook %d", cookID)
@@ -349,27 +982,74 @@ func (s *cookService) startWorker(ctx context.Context, cookID int) error {
 		defer s.wg.Done()
 		s.logger.Info("Worker started for cook %d", cookID)
 
-		ticker := time.NewTicker(100 * time.Millisecond)
-		defer ticker.Stop()
+		// waitCtx is cancelled on any stop signal so a parked DequeueWait returns immediately
+		waitCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
 
-		for {
+		stopReason := make(chan string, 1)
+		go func() {
 			select {
-			case <-ctx.Done():
-				s.logger.Info("Worker stopped for cook %d (context cancelled)", cookID)
-				return
 			case <-worker.stopChan:
-				s.logger.Info("Worker stopped for cook %d", cookID)
-				return
+				stopReason <- ""
 			case <-s.stopChan:
-				s.logger.Info("Worker stopped for cook %d (global stop)", cookID)
-				return
-			case <-ticker.C:
-				// Try to accept an order
-				_, err := s.AcceptOrder(ctx, cookID)
-				if err != nil {
-					// Continue to next iteration (ticker will wait 100ms)
+				stopReason <- " (global stop)"
+			case <-waitCtx.Done():
+				stopReason <- " (context cancelled)"
+			}
+			cancel()
+		}()
+
+		for {
+			cook, err := s.getActiveCook(waitCtx, cookID)
+			var slots *cookSlots
+			var order *domain.Order
+			if err == nil {
+				parkCtx, cancelPark, resumed := s.parkContext(waitCtx, worker)
+				if resumed != nil {
+					// Paused: take nothing new until resumed (orders in hand finish on their own)
+					s.logger.Info("Worker for cook %d paused", cookID)
+					select {
+					case <-resumed:
+						s.logger.Info("Worker for cook %d resumed", cookID)
+					case <-waitCtx.Done():
+					}
 					continue
 				}
+
+				// Only take an order once the cook has a free slot
+				slots = s.slotsFor(cook)
+				if err = slots.acquire(parkCtx); err == nil {
+					// Park until an order this cook can prepare arrives (no polling while the queue is empty)
+					if order, err = s.pipeline.Queue(cook.Stage).DequeueWaitFor(parkCtx, queue.Capabilities(cook.Capabilities)); err != nil {
+						slots.release()
+					}
+				}
+
+				interrupted := err != nil && parkCtx.Err() != nil && waitCtx.Err() == nil
+				cancelPark()
+				if interrupted {
+					// Woken by a pause - loop back and wait for resume
+					continue
+				}
+			}
+
+			if err != nil {
+				if waitCtx.Err() != nil {
+					s.logger.Info("Worker stopped for cook %d%s", cookID, <-stopReason)
+					return
+				}
+
+				// Unexpected error (e.g. repository failure) - back off before retrying
+				s.logger.Error("Worker for cook %d failed to accept order: %v", cookID, err)
+				select {
+				case <-time.After(workerRetryInterval):
+				case <-waitCtx.Done():
+				}
+				continue
+			}
+
+			if err := s.startOrder(ctx, cook, order, slots); err != nil {
+				s.logger.Error("Cook %d failed to start order %d: %v", cookID, order.ID, err)
 			}
 		}
 	}()
@@ -377,6 +1057,22 @@ func (s *cookService) startWorker(ctx context.Context, cookID int) error {
 	return nil
 }
 
+// parkContext returns the context for one wait for a slot and an order, cancelled early by PauseCook
+// While the cook is paused it returns the channel closed on resume instead (and a nil context)
+// Time Complexity: O(1)
+func (s *cookService) parkContext(ctx context.Context, worker *cookWorker) (context.Context, context.CancelFunc, <-chan struct{}) {
+	s.workersMu.Lock()
+	defer s.workersMu.Unlock()
+
+	if resumed, paused := s.paused[worker.cookID]; paused {
+		return nil, nil, resumed
+	}
+
+	parkCtx, cancel := context.WithCancel(ctx)
+	worker.interrupt = cancel
+	return parkCtx, cancel, nil
+}
+
 // stopWorker stops a specific worker
 func (s *cookService) stopWorker(cookID int) {
 	s.workersMu.Lock()
@@ -389,9 +1085,110 @@ func (s *cookService) stopWorker(cookID int) {
 	}
 }
 
+// CompleteOrder completes an order a manual cook is serving
+// Returns once the order is COMPLETE, so the cook's next AcceptOrder finds the slot free
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) CompleteOrder(ctx context.Context, cookID, orderID int) error {
+	cook, err := s.getActiveCook(ctx, cookID)
+	if err != nil {
+		return err
+	}
+
+	if !cook.CompletesManually() {
+		return ErrCookNotManual
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return ErrOrderNotFound
+	}
+
+	if order.AssignedCookUser == nil || *order.AssignedCookUser != cookID {
+		return ErrOrderNotAssignedToCook
+	}
+
+	if order.Status != domain.OrderStatusServing {
+		return ErrOrderNotServing
+	}
+
+	// Claim the handshake so the order completes once (it is gone if the order was just handed back)
+	s.manualMu.Lock()
+	waiter, exists := s.manual[orderID]
+	if exists && waiter.cookID == cookID {
+		delete(s.manual, orderID)
+	}
+	s.manualMu.Unlock()
+
+	if !exists {
+		return ErrOrderNotServing
+	}
+	if waiter.cookID != cookID {
+		return ErrOrderNotAssignedToCook
+	}
+
+	close(waiter.completed)
+	return <-waiter.result
+}
+
+// forgetManual drops a manual order's handshake once processing has ended
+// Time Complexity: O(1)
+func (s *cookService) forgetManual(orderID int) {
+	s.manualMu.Lock()
+	defer s.manualMu.Unlock()
+
+	delete(s.manual, orderID)
+}
+
+// DrainOrders waits for orders being cooked to complete until ctx is done
+// Orders still cooking then are reset to PENDING, unassigned and enqueued at the front
+// (orders of manual cooks straight away, as intake has stopped and they cannot be completed)
+// Call after StopWorkerPool (and after intake stopped) so no new orders start meanwhile
+// Time Complexity: O(n) where n is the number of orders still cooking
+func (s *cookService) DrainOrders(ctx context.Context) int {
+	s.startOnce.Do(func() { close(s.drainStart) })
+
+	done := make(chan struct{})
+	go func() {
+		s.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("All in-flight orders completed")
+	case <-ctx.Done():
+		s.logger.Info("Shutdown deadline reached - returning in-flight orders to queue")
+		s.drainOnce.Do(func() { close(s.drainAbort) })
+		<-done
+	}
+
+	return int(s.drained.Load())
+}
+
+// returnToQueue resets an order a cook will not finish to PENDING at the front of its stage's queue
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *cookService) returnToQueue(ctx context.Context, order *domain.Order) error {
+	if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusPending); err != nil {
+		return fmt.Errorf("failed to reset order status: %w", err)
+	}
+	if err := s.orderRepo.UnassignCook(ctx, order.ID); err != nil {
+		return fmt.Errorf("failed to unassign cook: %w", err)
+	}
+	if err := s.pipeline.Queue(order.Stage).EnqueueAtFront(order); err != nil {
+		return fmt.Errorf("failed to re-enqueue order: %w", err)
+	}
+	return nil
+}
+
 // StopWorkerPool stops all workers gracefully
 func (s *cookService) StopWorkerPool() {
 	s.logger.Info("Stopping worker pool")
+
+	// No new workers once stopping (cooks created from here on are manual only)
+	s.workersMu.Lock()
+	s.poolCtx = nil
+	s.workersMu.Unlock()
+
 	close(s.stopChan)
 	s.wg.Wait()
 	s.logger.Info("Worker pool stopped")
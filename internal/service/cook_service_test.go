@@ -0,0 +1,665 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/infrastructure/memory"
+	"mcmocknald-order-kiosk/internal/logger"
+	"mcmocknald-order-kiosk/pkg/queue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCookServiceTest creates a test environment for cook service tests
+func setupCookServiceTest(t *testing.T, servingDuration time.Duration) (CookService, OrderService, domain.UserRepository, domain.OrderRepository, queue.OrderQueue) {
+	ctx := context.Background()
+	log := logger.NewNoOpLogger()
+
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := queue.NewPriorityQueue()
+
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, OrderServiceConfig{ServingDuration: servingDuration})
+	cookService := NewCookService(userRepo, orderRepo, orderQueue, log, CookServiceConfig{ServingDuration: servingDuration, DefaultCapacity: 1})
+
+	// Create sample food items for tests
+	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+
+	return cookService, orderService, userRepo, orderRepo, orderQueue
+}
+
+// TestWorkerPicksUpOrderImmediately tests that an idle worker wakes as soon as an order is created
+func TestWorkerPicksUpOrderImmediately(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, orderRepo, orderQueue := setupCookServiceTest(t, time.Hour)
+
+	_, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 1))
+
+	order, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	// Well under the old 100ms polling interval
+	assert.Eventually(t, orderQueue.IsEmpty, 50*time.Millisecond, time.Millisecond,
+		"Idle worker should take the order as soon as it is enqueued")
+
+	cookService.StopWorkerPool()
+
+	taken, err := orderRepo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusServing, taken.Status, "Order should be SERVING")
+}
+
+// TestStopWorkerPoolReleasesIdleWorkers tests that parked workers exit promptly on shutdown
+func TestStopWorkerPoolReleasesIdleWorkers(t *testing.T) {
+	ctx := context.Background()
+	cookService, _, _, _, _ := setupCookServiceTest(t, time.Hour)
+
+	for i := 0; i < 5; i++ {
+		_, err := cookService.CreateCook(ctx, "Cook Bot", 0, nil, domain.CompletionModeTimer, "")
+		require.NoError(t, err)
+	}
+	require.NoError(t, cookService.StartWorkerPool(ctx, 5))
+
+	done := make(chan struct{})
+	go func() {
+		cookService.StopWorkerPool()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("StopWorkerPool did not return while workers were idle")
+	}
+}
+
+// TestSLABreachRecordedOnCompletion tests that an order completed after its deadline is counted as breached
+func TestSLABreachRecordedOnCompletion(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewNoOpLogger()
+
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := queue.NewPriorityQueue()
+
+	// VIP target shorter than cooking time: always breached; Regular target comfortably met
+	slaTargets := map[domain.RoleType]time.Duration{
+		domain.RoleVIPCustomer:     time.Millisecond,
+		domain.RoleRegularCustomer: time.Hour,
+	}
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, OrderServiceConfig{ServingDuration: 20 * time.Millisecond, SLATargets: slaTargets})
+	cookService := NewCookService(userRepo, orderRepo, orderQueue, log, CookServiceConfig{ServingDuration: 20 * time.Millisecond, DefaultCapacity: 1})
+
+	// Capacity 2 lets one cook take both orders back to back
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 2, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+
+	vip, err := userRepo.Create(ctx, &domain.User{Name: "VIP Customer", Role: domain.RoleVIPCustomer})
+	require.NoError(t, err)
+	regular, err := userRepo.Create(ctx, &domain.User{Name: "Regular Customer", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	for _, customerID := range []int{vip.ID, regular.ID} {
+		_, err := orderService.CreateOrder(ctx, customerID, []int{1})
+		require.NoError(t, err)
+		_, err = cookService.AcceptOrder(ctx, cook.ID)
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool {
+		stats, err := orderService.GetOrderStats(ctx)
+		return err == nil && stats.Completed == 2
+	}, time.Second, 5*time.Millisecond, "Both orders should complete")
+
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.SLABreached, "Only the VIP order should breach its SLA")
+}
+
+// TestAcceptOrderRespectsCookCapacity tests that a cook refuses orders beyond its capacity until a slot frees up
+func TestAcceptOrderRespectsCookCapacity(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, orderQueue := setupCookServiceTest(t, 50*time.Millisecond)
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, cook.Capacity, "Cook without its own capacity should use the service default")
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+
+	_, err = cookService.AcceptOrder(ctx, cook.ID)
+	require.NoError(t, err)
+
+	_, err = cookService.AcceptOrder(ctx, cook.ID)
+	assert.ErrorIs(t, err, ErrCookAtCapacity, "Second accept should be refused while the only slot is busy")
+	assert.Equal(t, 1, orderQueue.Size(), "Refused accept should leave the order queued")
+
+	// Slot frees once the first order completes
+	require.Eventually(t, func() bool {
+		_, err := cookService.AcceptOrder(ctx, cook.ID)
+		return err == nil
+	}, time.Second, 5*time.Millisecond, "Cook should accept again after completing its order")
+	assert.True(t, orderQueue.IsEmpty())
+}
+
+// TestWorkerAcceptsOnlyWithFreeSlot tests that a worker stops taking orders once every slot is busy
+func TestWorkerAcceptsOnlyWithFreeSlot(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, orderQueue := setupCookServiceTest(t, time.Hour)
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 2, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 1))
+	defer cookService.StopWorkerPool()
+
+	for i := 0; i < 3; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+
+	require.Eventually(t, func() bool { return orderQueue.Size() == 1 }, time.Second, time.Millisecond,
+		"Worker should fill both slots")
+
+	// Give the worker a chance to (wrongly) take the third order
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, 1, orderQueue.Size(), "Worker should not take an order without a free slot")
+
+	cooks, err := cookService.GetAllCooks(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, cooks, 1)
+	assert.Equal(t, cook.ID, cooks[0].ID)
+	assert.Equal(t, 2, cooks[0].Capacity)
+	require.NotNil(t, cooks[0].CurrentLoad)
+	assert.Equal(t, 2, *cooks[0].CurrentLoad, "Listing should report both busy slots")
+}
+
+// TestCreateCookRejectsNegativeCapacity tests that a negative capacity is refused
+func TestCreateCookRejectsNegativeCapacity(t *testing.T) {
+	cookService, _, _, _, _ := setupCookServiceTest(t, time.Hour)
+
+	_, err := cookService.CreateCook(context.Background(), "Cook Bot 1", -1, nil, domain.CompletionModeTimer, "")
+	assert.ErrorIs(t, err, ErrInvalidCookCapacity)
+}
+
+// TestStationCookAcceptsOnlyOrdersItCanPrepare tests that a drink station skips food orders
+func TestStationCookAcceptsOnlyOrdersItCanPrepare(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewNoOpLogger()
+
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := queue.NewPriorityQueue()
+
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, OrderServiceConfig{ServingDuration: time.Hour})
+	cookService := NewCookService(userRepo, orderRepo, orderQueue, log, CookServiceConfig{ServingDuration: time.Hour, DefaultCapacity: 2})
+
+	burger, err := foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+	require.NoError(t, err)
+	cola, err := foodRepo.Create(ctx, &domain.Food{Name: "Cola", Type: domain.FoodTypeDrink})
+	require.NoError(t, err)
+
+	station, err := cookService.CreateCook(ctx, "Drink Station", 0, []domain.FoodType{domain.FoodTypeDrink}, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	assert.Equal(t, []domain.FoodType{domain.FoodTypeDrink}, station.Capabilities)
+
+	// Staff cook who can prepare everything (accepts by hand, so takes nothing during the test)
+	_, err = cookService.CreateCook(ctx, "Line Cook", 0, nil, domain.CompletionModeManual, "")
+	require.NoError(t, err)
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	foodOrder, err := orderService.CreateOrder(ctx, customer.ID, []int{burger.ID})
+	require.NoError(t, err)
+	mixedOrder, err := orderService.CreateOrder(ctx, customer.ID, []int{burger.ID, cola.ID})
+	require.NoError(t, err)
+	drinkOrder, err := orderService.CreateOrder(ctx, customer.ID, []int{cola.ID})
+	require.NoError(t, err)
+
+	taken, err := cookService.AcceptOrder(ctx, station.ID)
+	require.NoError(t, err)
+	assert.Equal(t, drinkOrder.ID, taken.ID, "Drink station should skip food and mixed orders")
+
+	_, err = cookService.AcceptOrder(ctx, station.ID)
+	assert.Error(t, err, "Nothing left the drink station can prepare")
+
+	position, err := orderQueue.Position(foodOrder.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, position, "Skipped orders keep their place")
+	position, err = orderQueue.Position(mixedOrder.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, position)
+}
+
+// TestCreateCookRejectsUnknownCapability tests that capabilities must be known food types
+func TestCreateCookRejectsUnknownCapability(t *testing.T) {
+	cookService, _, _, _, _ := setupCookServiceTest(t, time.Hour)
+
+	_, err := cookService.CreateCook(context.Background(), "Cook Bot 1", 0, []domain.FoodType{"Soup"}, domain.CompletionModeTimer, "")
+	assert.ErrorIs(t, err, ErrInvalidCookCapability)
+}
+
+// TestProcessOrderUsesItemPrepTime tests that cooking takes the order's item prep time, not the global default
+func TestProcessOrderUsesItemPrepTime(t *testing.T) {
+	ctx := context.Background()
+	cookService, orders, userRepo, _, _ := setupCookServiceTest(t, time.Hour)
+
+	water, err := orders.(*orderService).foodRepo.Create(ctx, &domain.Food{Name: "Water", Type: domain.FoodTypeDrink, PrepTimeMs: 20})
+	require.NoError(t, err)
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	_, err = orders.CreateOrder(ctx, customer.ID, []int{water.ID})
+	require.NoError(t, err)
+	_, err = cookService.AcceptOrder(ctx, cook.ID)
+	require.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		stats, err := orders.GetOrderStats(ctx)
+		return err == nil && stats.Completed == 1
+	}, time.Second, 5*time.Millisecond, "A 20ms item should not wait for the 1h default")
+}
+
+// TestPausedCookFinishesCurrentOrderButTakesNoNew tests that pausing stops new work without bouncing orders in hand
+func TestPausedCookFinishesCurrentOrderButTakesNoNew(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, orderQueue := setupCookServiceTest(t, 50*time.Millisecond)
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 1))
+	defer cookService.StopWorkerPool()
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	require.Eventually(t, orderQueue.IsEmpty, time.Second, time.Millisecond, "Worker should take the first order")
+
+	require.NoError(t, cookService.PauseCook(ctx, cook.ID))
+	assert.ErrorIs(t, cookService.PauseCook(ctx, cook.ID), ErrCookPaused, "Pausing twice should be refused")
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		stats, err := orderService.GetOrderStats(ctx)
+		return err == nil && stats.Completed == 1
+	}, time.Second, 5*time.Millisecond, "Order in hand should still complete")
+
+	// Well past the 50ms prep time of the second order
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, 1, orderQueue.Size(), "Paused cook should not take the new order")
+
+	_, err = cookService.AcceptOrder(ctx, cook.ID)
+	assert.ErrorIs(t, err, ErrCookPaused, "Manual accept should be refused while paused")
+
+	current, err := cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStatePaused, current.WorkerState)
+
+	require.NoError(t, cookService.ResumeCook(ctx, cook.ID))
+	assert.Eventually(t, orderQueue.IsEmpty, time.Second, time.Millisecond, "Resumed cook should take the waiting order")
+
+	current, err = cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStateRunning, current.WorkerState)
+}
+
+// TestResumeCookRequiresPause tests that resuming a cook that is not paused is refused
+func TestResumeCookRequiresPause(t *testing.T) {
+	ctx := context.Background()
+	cookService, _, _, _, _ := setupCookServiceTest(t, time.Hour)
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStateStopped, cook.WorkerState, "Cook without a worker should be reported as stopped")
+
+	assert.ErrorIs(t, cookService.ResumeCook(ctx, cook.ID), ErrCookNotPaused)
+}
+
+// TestStartWorkerPoolCreatesMissingCooks tests that the pool tops the active cooks up to the requested count
+func TestStartWorkerPoolCreatesMissingCooks(t *testing.T) {
+	ctx := context.Background()
+	cookService, _, _, _, _ := setupCookServiceTest(t, time.Hour)
+
+	_, err := cookService.CreateCook(ctx, "Existing Cook", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 3))
+	defer cookService.StopWorkerPool()
+
+	cooks, err := cookService.GetAllCooks(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, cooks, 3, "Existing cook should count towards the initial cook bots")
+	for _, cook := range cooks {
+		assert.Equal(t, domain.WorkerStateRunning, cook.WorkerState, "Cook %d should have a worker", cook.ID)
+	}
+}
+
+// TestWorkerLifecycleFollowsCookLifecycle tests that create and reinstate start a worker and remove stops it
+func TestWorkerLifecycleFollowsCookLifecycle(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, orderQueue := setupCookServiceTest(t, time.Hour)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 0))
+	defer cookService.StopWorkerPool()
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStateRunning, cook.WorkerState, "Cook created while the pool runs should get a worker")
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	require.Eventually(t, orderQueue.IsEmpty, time.Second, time.Millisecond, "New cook's worker should take the order")
+
+	require.NoError(t, cookService.RemoveCook(ctx, cook.ID))
+	removed, err := cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStateStopped, removed.WorkerState, "Removing a cook should stop its worker")
+	assert.Equal(t, 1, orderQueue.Size(), "Order in hand should return to the queue")
+
+	require.NoError(t, cookService.ReinstateCook(ctx, cook.ID))
+	reinstated, err := cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStateRunning, reinstated.WorkerState, "Reinstating a cook should restart its worker")
+	assert.Eventually(t, orderQueue.IsEmpty, time.Second, time.Millisecond, "Reinstated cook's worker should take the order again")
+}
+
+// TestDrainOrdersLetsServingOrderFinish tests that orders in hand complete after the pool stops
+func TestDrainOrdersLetsServingOrderFinish(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, orderQueue := setupCookServiceTest(t, 50*time.Millisecond)
+
+	_, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 1))
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	require.Eventually(t, orderQueue.IsEmpty, time.Second, time.Millisecond, "Worker should take the order")
+
+	cookService.StopWorkerPool()
+
+	drainCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	assert.Equal(t, 0, cookService.DrainOrders(drainCtx), "Order should finish well within the deadline")
+
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Completed)
+}
+
+// TestDrainOrdersReturnsUnfinishedOrdersAtDeadline tests that orders still cooking at the deadline go back to the queue front
+func TestDrainOrdersReturnsUnfinishedOrdersAtDeadline(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, orderRepo, orderQueue := setupCookServiceTest(t, time.Hour)
+
+	_, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 1))
+	cooking, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	require.Eventually(t, orderQueue.IsEmpty, time.Second, time.Millisecond, "Worker should take the order")
+
+	cookService.StopWorkerPool()
+
+	// Placed after the pool stopped, so it is still waiting
+	waiting, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	drainCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 1, cookService.DrainOrders(drainCtx))
+
+	returned, err := orderRepo.GetByID(ctx, cooking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPending, returned.Status)
+	assert.False(t, returned.HasAssignedCook())
+
+	require.Equal(t, 2, orderQueue.Size())
+	front, err := orderQueue.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, cooking.ID, front.ID, "Returned order should be served before orders placed after it started")
+	assert.NotEqual(t, waiting.ID, front.ID)
+}
+
+// TestManualCookOrderStaysServingUntilCompleted tests that a manual cook's order completes only on CompleteOrder
+func TestManualCookOrderStaysServingUntilCompleted(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, orderQueue := setupCookServiceTest(t, 10*time.Millisecond)
+
+	cook, err := cookService.CreateCook(ctx, "Tablet Cook", 0, nil, domain.CompletionModeManual, "")
+	require.NoError(t, err)
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 0))
+	defer cookService.StopWorkerPool()
+
+	started, err := cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.WorkerStateStopped, started.WorkerState, "Manual cooks take orders themselves, without a worker")
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	time.Sleep(30 * time.Millisecond)
+	assert.Equal(t, 1, orderQueue.Size(), "No worker should take orders for a manual cook")
+
+	order, err := cookService.AcceptOrder(ctx, cook.ID)
+	require.NoError(t, err)
+
+	// Well past the 10ms prep time
+	time.Sleep(50 * time.Millisecond)
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Completed, "Order should stay SERVING until the cook completes it")
+
+	require.NoError(t, cookService.CompleteOrder(ctx, cook.ID, order.ID))
+
+	stats, err = orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Completed, "Order should be COMPLETE once CompleteOrder returns")
+
+	current, err := cookService.GetCook(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, *current.CurrentLoad, "Completing should free the cook's slot")
+}
+
+// TestIsServingTracksHeldOrders tests that an order counts as held from acceptance until its cook is done with it
+func TestIsServingTracksHeldOrders(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, _ := setupCookServiceTest(t, 10*time.Millisecond)
+
+	cook, err := cookService.CreateCook(ctx, "Tablet Cook", 0, nil, domain.CompletionModeManual, "")
+	require.NoError(t, err)
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	order, err := cookService.AcceptOrder(ctx, cook.ID)
+	require.NoError(t, err)
+	assert.True(t, cookService.IsServing(order.ID), "Order waiting for its manual cook should be held")
+
+	require.NoError(t, cookService.CompleteOrder(ctx, cook.ID, order.ID))
+	assert.Eventually(t, func() bool { return !cookService.IsServing(order.ID) },
+		time.Second, time.Millisecond, "Completed order should no longer be held")
+}
+
+// TestCompleteOrderChecksCookAndAssignment tests the refusals of CompleteOrder
+func TestCompleteOrderChecksCookAndAssignment(t *testing.T) {
+	ctx := context.Background()
+	cookService, orderService, userRepo, _, _ := setupCookServiceTest(t, time.Hour)
+
+	bot, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	cook, err := cookService.CreateCook(ctx, "Tablet Cook 1", 0, nil, domain.CompletionModeManual, "")
+	require.NoError(t, err)
+	other, err := cookService.CreateCook(ctx, "Tablet Cook 2", 0, nil, domain.CompletionModeManual, "")
+	require.NoError(t, err)
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	order, err := cookService.AcceptOrder(ctx, cook.ID)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, cookService.CompleteOrder(ctx, bot.ID, order.ID), ErrCookNotManual)
+	assert.ErrorIs(t, cookService.CompleteOrder(ctx, other.ID, order.ID), ErrOrderNotAssignedToCook)
+	assert.ErrorIs(t, cookService.CompleteOrder(ctx, cook.ID, 999), ErrOrderNotFound)
+
+	require.NoError(t, cookService.CompleteOrder(ctx, cook.ID, order.ID))
+	assert.ErrorIs(t, cookService.CompleteOrder(ctx, cook.ID, order.ID), ErrOrderNotServing, "An order completes once")
+
+	_, err = cookService.CreateCook(ctx, "Cook Bot 2", 0, nil, "robot", "")
+	assert.ErrorIs(t, err, ErrInvalidCompletionMode)
+}
+
+// TestPipelineOrderPassesThroughEveryStage tests that an order is handled by each stage's cook in turn and completes after the last
+func TestPipelineOrderPassesThroughEveryStage(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewNoOpLogger()
+
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := queue.NewPriorityQueue()
+
+	// cook has no duration of its own: it takes the order's item prep time
+	stages := []domain.PipelineStage{
+		{Name: "prep", Duration: 10 * time.Millisecond},
+		{Name: "cook"},
+		{Name: "pack", Duration: 10 * time.Millisecond},
+	}
+	pipeline, err := NewPipeline(stages, orderQueue, func() (queue.OrderQueue, error) { return queue.NewPriorityQueue(), nil })
+	require.NoError(t, err)
+
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, OrderServiceConfig{ServingDuration: 10 * time.Millisecond, Pipeline: pipeline})
+	cookService := NewCookService(userRepo, orderRepo, orderQueue, log, CookServiceConfig{ServingDuration: 10 * time.Millisecond, DefaultCapacity: 1, Pipeline: pipeline})
+	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	// One cook bot per stage
+	require.NoError(t, cookService.StartWorkerPool(ctx, 1))
+	defer cookService.StopWorkerPool()
+
+	cooks, err := cookService.GetAllCooks(ctx, false)
+	require.NoError(t, err)
+	require.Len(t, cooks, 3)
+	cookByStage := make(map[string]int)
+	for _, cook := range cooks {
+		cookByStage[cook.Stage] = cook.ID
+	}
+
+	order, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+	assert.Equal(t, "prep", order.Stage, "New orders should start in the first stage")
+
+	require.Eventually(t, func() bool {
+		stats, err := orderService.GetOrderStats(ctx)
+		return err == nil && stats.Completed == 1
+	}, time.Second, 5*time.Millisecond, "Order should complete after the last stage")
+
+	completed, err := orderRepo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "pack", completed.Stage)
+	require.Len(t, completed.Stages, 3, "Each stage should be recorded once")
+	for i, stage := range stages {
+		record := completed.Stages[i]
+		assert.Equal(t, stage.Name, record.Stage)
+		assert.Equal(t, cookByStage[stage.Name], record.CookID, "Stage %s should be handled by its own cook", stage.Name)
+		require.NotNil(t, record.CompletedAt, "Stage %s should be completed", stage.Name)
+		assert.False(t, record.CompletedAt.Before(record.StartedAt))
+	}
+}
+
+// TestCreateCookRejectsUnknownStage tests that cooks can only be created for configured stages
+func TestCreateCookRejectsUnknownStage(t *testing.T) {
+	ctx := context.Background()
+	cookService, _, _, _, _ := setupCookServiceTest(t, time.Hour)
+
+	_, err := cookService.CreateCook(ctx, "Cook Bot 1", 0, nil, domain.CompletionModeTimer, "pack")
+	assert.ErrorIs(t, err, ErrUnknownStage, "Without a pipeline only the default stage exists")
+
+	cook, err := cookService.CreateCook(ctx, "Cook Bot 2", 0, nil, domain.CompletionModeTimer, "")
+	require.NoError(t, err)
+	assert.Empty(t, cook.Stage)
+}
+
+// TestFailedAttemptsRetryOrderThenMarkItFailed tests that a failed order is retried until it runs out of attempts
+func TestFailedAttemptsRetryOrderThenMarkItFailed(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewNoOpLogger()
+
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := queue.NewPriorityQueue()
+
+	// Every attempt fails
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, OrderServiceConfig{ServingDuration: 5 * time.Millisecond})
+	cookService := NewCookService(userRepo, orderRepo, orderQueue, log, CookServiceConfig{ServingDuration: 5 * time.Millisecond, DefaultCapacity: 1, Failures: FailureConfig{Rate: 1, MaxAttempts: 3}})
+	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	order, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	require.NoError(t, cookService.StartWorkerPool(ctx, 1))
+	require.Eventually(t, func() bool {
+		stats, err := orderService.GetOrderStats(ctx)
+		return err == nil && stats.Failed == 1
+	}, time.Second, 5*time.Millisecond, "Order should be FAILED after its last attempt")
+	cookService.StopWorkerPool()
+
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Completed)
+	assert.Equal(t, 0, stats.Incomplete, "FAILED orders are no longer incomplete")
+
+	failed, err := orderRepo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusFailed, failed.Status)
+	assert.Equal(t, 3, failed.Attempts, "Order should have been tried MaxAttempts times")
+	assert.False(t, failed.HasAssignedCook())
+	assert.True(t, orderQueue.IsEmpty(), "FAILED order should not be queued")
+}
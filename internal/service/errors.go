@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrOrderNotFound is returned when an order does not exist
+	ErrOrderNotFound = errors.New("order not found")
+
+	// ErrOrderNotCancellable is returned when cancelling an order a cook has already taken (or that is finished)
+	ErrOrderNotCancellable = errors.New("order can no longer be cancelled")
+
+	// ErrOrderNotFailed is returned when requeueing an order that has not failed
+	ErrOrderNotFailed = errors.New("order has not failed")
+
+	// ErrOrderNotQueued is returned when reordering an order that is not waiting in the queue
+	ErrOrderNotQueued = errors.New("order is not in the queue")
+
+	// ErrUnknownPriorityClass is returned when moving an order to a priority class that does not exist
+	ErrUnknownPriorityClass = errors.New("unknown priority class")
+
+	// ErrCookAtCapacity is returned when a cook is already serving as many orders as its capacity allows
+	ErrCookAtCapacity = errors.New("cook is at capacity")
+
+	// ErrCookPaused is returned when a paused cook is asked to take an order (or paused again)
+	ErrCookPaused = errors.New("cook is paused")
+
+	// ErrCookNotPaused is returned when resuming a cook that is not paused
+	ErrCookNotPaused = errors.New("cook is not paused")
+
+	// ErrInvalidCookCapacity is returned when creating a cook with a negative capacity
+	ErrInvalidCookCapacity = errors.New("cook capacity must be non-negative")
+
+	// ErrInvalidCookCapability is returned when creating a cook with an unknown food type capability
+	ErrInvalidCookCapability = errors.New("invalid cook capability")
+
+	// ErrInvalidCompletionMode is returned when creating a cook with an unknown completion mode
+	ErrInvalidCompletionMode = errors.New("invalid completion mode")
+
+	// ErrUnknownStage is returned when creating a cook for a pipeline stage that is not configured
+	ErrUnknownStage = errors.New("unknown pipeline stage")
+
+	// ErrCookNotManual is returned when a cook whose orders complete on a timer tries to complete one
+	ErrCookNotManual = errors.New("cook does not complete orders manually")
+
+	// ErrOrderNotAssignedToCook is returned when a cook completes an order another cook (or none) is serving
+	ErrOrderNotAssignedToCook = errors.New("order is not assigned to this cook")
+
+	// ErrOrderNotServing is returned when completing an order that is not being served
+	ErrOrderNotServing = errors.New("order is not being served")
+
+	// ErrNoCapableCook is returned when creating an order none of the cooks on a stage can prepare whole
+	ErrNoCapableCook = errors.New("no cook can prepare every item of the order")
+)
+
+// QueueFullError is returned when admission control refuses a new order
+// RetryAfter estimates when enough queued orders will have been taken for a new one to fit
+// Unwraps to the queue's *queue.QueueFullError (so errors.Is(err, queue.ErrQueueFull) matches)
+type QueueFullError struct {
+	Cause      error         // Limit that refused the order
+	RetryAfter time.Duration // Hint for clients (Retry-After header)
+}
+
+// Error implements error
+func (e *QueueFullError) Error() string {
+	return e.Cause.Error()
+}
+
+// Unwrap exposes the underlying queue error
+func (e *QueueFullError) Unwrap() error {
+	return e.Cause
+}
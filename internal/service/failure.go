@@ -0,0 +1,18 @@
+package service
+
+import (
+	"math/rand"
+)
+
+// FailureConfig configures simulated cooking failures (failure injection to exercise error handling)
+// A failed attempt returns the order to the front of its queue; after MaxAttempts it is FAILED
+type FailureConfig struct {
+	Rate        float64 // Probability that a cook bot's attempt at an order fails (0 = never, 1 = always)
+	MaxAttempts int     // Failed attempts after which an order is FAILED (minimum 1)
+}
+
+// attemptFails rolls whether one cooking attempt fails
+// Time Complexity: O(1)
+func (f FailureConfig) attemptFails() bool {
+	return f.Rate > 0 && rand.Float64() < f.Rate
+}
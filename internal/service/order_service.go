@@ -2,7 +2,11 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"mcmocknald-order-kiosk/internal/domain"
@@ -16,14 +20,57 @@ type OrderService interface {
 	// CreateOrder creates a new order and adds it to the queue
 	CreateOrder(ctx context.Context, customerID int, foodIDs []int) (*domain.Order, error)
 
-	// GetOrder retrieves an order by ID
+	// GetOrder retrieves an order by ID, with queue position and ETA while it is PENDING or SERVING
 	GetOrder(ctx context.Context, orderID int) (*domain.Order, error)
 
-	// GetOrderStats retrieves order statistics
-	GetOrderStats(ctx context.Context) (completed, incomplete int, err error)
+	// CancelOrder cancels a pending order and removes it from the queue
+	// Rejected with ErrOrderNotCancellable once a cook has taken the order
+	CancelOrder(ctx context.Context, orderID int) (*domain.Order, error)
 
-	// GetQueueSize returns the current queue size
+	// RequeueOrder puts a FAILED order back at the end of its queue with its attempts reset
+	// Rejected with ErrOrderNotFailed for orders that have not failed
+	RequeueOrder(ctx context.Context, orderID int) (*domain.Order, error)
+
+	// GetOrderStats retrieves order statistics (completed, incomplete, failed, SLA breaches and recovered orders)
+	GetOrderStats(ctx context.Context) (domain.OrderStats, error)
+
+	// GetQueueSize returns the number of orders waiting in every stage queue
 	GetQueueSize() int
+
+	// GetStageQueueSize returns the number of orders waiting for one pipeline stage (empty = intake)
+	GetStageQueueSize(stage string) int
+
+	// RestoreQueue reloads unfinished orders from the repository into the queue (startup rehydration)
+	// Orders left SERVING by a previous process are reset to PENDING first
+	RestoreQueue(ctx context.Context) (int, error)
+
+	// RecoverStuckOrders returns SERVING orders no cook is working on any more (e.g. orphaned by a crashed
+	// instance) to the front of the queue, reporting how many were recovered
+	// Orders a cook of this process holds are never recovered. Others are once SERVING for longer than
+	// multiple times their expected cook time; orders of manual cooks only when no other instance can hold them
+	// With a shared queue, PENDING orders a crashed instance left outside the queue are requeued as well
+	RecoverStuckOrders(ctx context.Context, multiple int) (int, error)
+}
+
+// CookRoster lists cooks with their worker state and current load (implemented by CookService)
+// Following Interface Segregation Principle: the order service only needs to see who is cooking
+type CookRoster interface {
+	GetAllCooks(ctx context.Context, includeDeleted bool) ([]*domain.User, error)
+}
+
+// orderHolder is implemented by rosters that know which orders their cooks hold (CookService)
+// The user repository does not, so without the cook service stuck orders are judged by elapsed time alone
+type orderHolder interface {
+	IsServing(orderID int) bool
+}
+
+// OrderServiceConfig tunes how the order service times and routes orders
+type OrderServiceConfig struct {
+	ServingDuration time.Duration                     // Prep time of items without their own
+	SLATargets      map[domain.RoleType]time.Duration // Customer role -> time allowed from creation to completion (nil = no deadlines)
+	ItemParallelism int                               // Items of an order a cook prepares at once (0 = all of them)
+	Pipeline        *Pipeline                         // Kitchen stages (nil = one stage over the order queue)
+	Cooks           CookRoster                        // Cooks with worker state (nil = the user repository, every cook counted as running)
 }
 
 // orderService implements order business logic
@@ -33,12 +80,19 @@ type orderService struct {
 	orderRepo       domain.OrderRepository
 	userRepo        domain.UserRepository
 	foodRepo        domain.FoodRepository
-	orderQueue      queue.OrderQueue
+	cooks           CookRoster       // Cooks with worker state (ETAs and Retry-After count the running ones)
+	orderQueue      queue.OrderQueue // Intake queue new orders join (the first pipeline stage's queue)
+	pipeline        *Pipeline        // Stage queues later stages are restored and recovered into
 	logger          logger.Logger
 	servingDuration time.Duration
+	slaTargets      map[domain.RoleType]time.Duration // Customer role -> time allowed from creation to completion
+	prep            prepTimer                         // Order cook time from its items (ETAs, Retry-After)
+	recovered       atomic.Int64                      // Stuck orders returned to the queue since start
+	requeueMu       sync.Mutex                        // Serializes manual requeues (an order is requeued once)
 }
 
 // NewOrderService creates a new order service
+// orderQueue is the intake queue new orders join
 // Following Dependency Injection pattern
 func NewOrderService(
 	orderRepo domain.OrderRepository,
@@ -46,15 +100,28 @@ func NewOrderService(
 	foodRepo domain.FoodRepository,
 	orderQueue queue.OrderQueue,
 	log logger.Logger,
-	servingDuration time.Duration,
+	config OrderServiceConfig,
 ) OrderService {
+	pipeline := config.Pipeline
+	if pipeline == nil {
+		pipeline = singleStagePipeline(orderQueue)
+	}
+	cooks := config.Cooks
+	if cooks == nil {
+		cooks = userRepo
+	}
+
 	return &orderService{
 		orderRepo:       orderRepo,
 		userRepo:        userRepo,
 		foodRepo:        foodRepo,
+		cooks:           cooks,
 		orderQueue:      orderQueue,
+		pipeline:        pipeline,
 		logger:          log,
-		servingDuration: servingDuration,
+		servingDuration: config.ServingDuration,
+		slaTargets:      config.SLATargets,
+		prep:            prepTimer{defaultPrepTime: config.ServingDuration, parallelism: config.ItemParallelism},
 	}
 }
 
@@ -81,15 +148,38 @@ func (s *orderService) CreateOrder(ctx context.Context, customerID int, foodIDs
 	}
 
 	// Validate food IDs
-	if err := s.validateFoodIDs(ctx, foodIDs); err != nil {
+	foods, err := s.validateFoodIDs(ctx, foodIDs)
+	if err != nil {
 		s.logger.Error("Food validation failed: %v", err)
 		return nil, err
 	}
 
-	// Create order in repository
+	// Build the order with customer data and foods, so admission classifies it as the queue will
+	// (foods route it to capable cooks)
 	order := &domain.Order{
-		Status:    domain.OrderStatusPending,
-		OrderedBy: customerID,
+		Status:       domain.OrderStatusPending,
+		OrderedBy:    customerID,
+		Stage:        s.pipeline.First(),
+		CustomerName: customer.Name,
+		CustomerRole: customer.Role,
+		Foods:        foods,
+	}
+
+	// Set the SLA deadline from the customer's role (roles without a target have none)
+	if target, exists := s.slaTargets[customer.Role]; exists {
+		deadline := time.Now().Add(target)
+		order.Deadline = &deadline
+	}
+
+	// Refuse an order the cooks' capabilities would leave waiting forever
+	if err := s.checkPreparable(ctx, order); err != nil {
+		s.logger.Error("Order from customer %s (%s) refused - %v", customer.Name, customer.Role, err)
+		return nil, err
+	}
+
+	// Admission control: refuse the order before it is persisted if the queue is already full
+	if err := s.orderQueue.Admit(order); err != nil {
+		return nil, s.admissionError(ctx, customer, err)
 	}
 
 	createdOrder, err := s.orderRepo.Create(ctx, order, foodIDs)
@@ -98,12 +188,21 @@ func (s *orderService) CreateOrder(ctx context.Context, customerID int, foodIDs
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
-	// Enrich order with customer data for queue
+	// Enrich order with customer data and foods for queue (the repository returns the stored columns)
 	createdOrder.CustomerName = customer.Name
 	createdOrder.CustomerRole = customer.Role
+	createdOrder.Foods = foods
 
-	// Add to priority queue
-	if err := s.orderQueue.Enqueue(createdOrder); err != nil {
+	// Add to priority queue, re-checking the limits atomically: concurrent orders may have filled
+	// the queue since the pre-check, in which case the persisted order is soft deleted (a refusal is
+	// not a cancellation, so it stays out of order history and stats)
+	if err := s.orderQueue.AdmitAndEnqueue(createdOrder); err != nil {
+		if errors.Is(err, queue.ErrQueueFull) {
+			if deleteErr := s.orderRepo.SoftDelete(ctx, createdOrder.ID); deleteErr != nil {
+				s.logger.Error("Failed to delete refused order %d: %v", createdOrder.ID, deleteErr)
+			}
+			return nil, s.admissionError(ctx, customer, err)
+		}
 		s.logger.Error("Failed to enqueue order %d: %v", createdOrder.ID, err)
 		return nil, fmt.Errorf("failed to enqueue order: %w", err)
 	}
@@ -114,8 +213,123 @@ func (s *orderService) CreateOrder(ctx context.Context, customerID int, foodIDs
 	return createdOrder, nil
 }
 
-// GetOrder retrieves an order by ID
-// Time Complexity: O(1) for in-memory, O(log n) for database
+// admissionError converts a failed admission into the error returned to the customer
+// A full queue becomes a QueueFullError carrying the suggested retry delay
+// Time Complexity: O(n + e * c), see retryAfter
+func (s *orderService) admissionError(ctx context.Context, customer *domain.User, err error) error {
+	var full *queue.QueueFullError
+	if errors.As(err, &full) {
+		retryAfter := s.retryAfter(ctx, full.Excess())
+		s.logger.Info("Order from customer %s (%s) refused - %v - Retry after %v",
+			customer.Name, customer.Role, err, retryAfter)
+		return &QueueFullError{Cause: err, RetryAfter: retryAfter}
+	}
+	s.logger.Error("Failed to check queue admission: %v", err)
+	return fmt.Errorf("failed to check queue admission: %w", err)
+}
+
+// retryAfter estimates how long until excess queued orders have been cooked at the current throughput
+// The first excess orders in line are spread over the active intake cooks by their prep times (same model
+// as estimateQueueTimes); with no active cooks nothing drains, so one servingDuration is suggested
+// as a polling interval
+// Time Complexity: O(n + e * c) where n is queued orders, e is excess and c is the number of cooks
+func (s *orderService) retryAfter(ctx context.Context, excess int) time.Duration {
+	cooks, err := s.activeCooks(ctx, "")
+	if err != nil || cooks == 0 || excess < 1 {
+		return s.servingDuration
+	}
+
+	_, makespan := scheduleLanes(s.queuedPrepTimes(s.orderQueue, 0, excess), cooks)
+	return makespan
+}
+
+// checkPreparable refuses an order that would never be dequeued: a stage has cooks, but none of them
+// can prepare every item (orders are routed whole, see queue.Capabilities)
+// Paused and manual cooks count, as they take orders again; a stage without cooks is a staffing gap,
+// not a routing one, so the order is accepted and waits for a cook to be added
+// Time Complexity: O(c * f * t) where c is cooks, f is foods in the order and t is types per cook
+func (s *orderService) checkPreparable(ctx context.Context, order *domain.Order) error {
+	cooks, err := s.cooks.GetAllCooks(ctx, false)
+	if err != nil {
+		s.logger.Error("Failed to get cooks: %v", err)
+		return fmt.Errorf("failed to get cooks: %w", err)
+	}
+
+	staffed := make(map[string]bool) // Stage -> whether one of its cooks can prepare the order
+	for _, cook := range cooks {
+		if cook.IsDeleted() {
+			continue
+		}
+		stage := s.pipeline.resolve(cook.Stage)
+		staffed[stage] = staffed[stage] || queue.Capabilities(cook.Capabilities).CanPrepare(order)
+	}
+
+	for stage, capable := range staffed {
+		if capable {
+			continue
+		}
+		if stage == "" {
+			return ErrNoCapableCook
+		}
+		return fmt.Errorf("%w at stage %s", ErrNoCapableCook, stage)
+	}
+	return nil
+}
+
+// activeCooks counts the cooks draining a stage's queue on their own (empty = intake stage)
+// Only running, timer-driven cooks working that stage count: paused and stopped cooks take nothing,
+// and manual cooks take orders whenever staff accept them, so they add no predictable throughput
+// Cooks without a worker state (listed straight from the user repository) count as running
+// Time Complexity: O(c) where c is the number of cooks
+func (s *orderService) activeCooks(ctx context.Context, stage string) (int, error) {
+	cooks, err := s.cooks.GetAllCooks(ctx, false)
+	if err != nil {
+		return 0, err
+	}
+
+	stage = s.pipeline.resolve(stage)
+	active := 0
+	for _, cook := range cooks {
+		if cook.IsDeleted() || cook.CompletesManually() || s.pipeline.resolve(cook.Stage) != stage {
+			continue
+		}
+		if cook.WorkerState != "" && cook.WorkerState != domain.WorkerStateRunning {
+			continue
+		}
+		active++
+	}
+	return active, nil
+}
+
+// queuedPrepTimes returns the stage durations of orders in a stage queue in service order (strict class order)
+// Stops before the order stopAt (0 = none); with limit > 0 returns exactly limit entries,
+// padding with servingDuration when fewer orders are queued
+// Time Complexity: O(n) where n is the number of queued orders
+func (s *orderService) queuedPrepTimes(stageQueue queue.OrderQueue, stopAt, limit int) []time.Duration {
+	snapshot, err := stageQueue.Snapshot()
+	if err != nil {
+		s.logger.Error("Failed to snapshot queue for estimates: %v", err)
+	}
+
+	var times []time.Duration
+collect:
+	for _, class := range snapshot {
+		for _, queued := range class.Orders {
+			if queued.Order.ID == stopAt || (limit > 0 && len(times) == limit) {
+				break collect
+			}
+			times = append(times, s.pipeline.duration(queued.Order, s.prep))
+		}
+	}
+
+	for len(times) < limit {
+		times = append(times, s.servingDuration)
+	}
+	return times
+}
+
+// GetOrder retrieves an order by ID, with queue position and ETA while it is PENDING or SERVING
+// Time Complexity: O(1) for in-memory, O(log n) for database, plus O(k) for the queue position
 func (s *orderService) GetOrder(ctx context.Context, orderID int) (*domain.Order, error) {
 	order, err := s.orderRepo.GetByID(ctx, orderID)
 	if err != nil {
@@ -123,53 +337,430 @@ func (s *orderService) GetOrder(ctx context.Context, orderID int) (*domain.Order
 		return nil, err
 	}
 
-	return order, nil
+	// Copy so the estimates never leak into repository-owned data
+	enriched := *order
+	s.estimateQueueTimes(ctx, &enriched, time.Now())
+
+	return &enriched, nil
+}
+
+// estimateQueueTimes fills in queue position and estimated start/completion times
+// A PENDING order starts once a cook is free after every order ahead of it in its stage queue has
+// been handed out (each order takes its own stage duration, next order to whichever cook frees up
+// first). An order completes its stage duration after it starts. Only running timer cooks of the order's
+// stage are counted (see activeCooks); estimates are omitted when there are none.
+// Time Complexity: O(n + k * c) where n is queued orders, k is orders ahead and c is the number of cooks
+func (s *orderService) estimateQueueTimes(ctx context.Context, order *domain.Order, now time.Time) {
+	switch order.Status {
+	case domain.OrderStatusPending:
+		stageQueue := s.pipeline.Queue(order.Stage)
+		position, err := stageQueue.Position(order.ID)
+		if err != nil {
+			// Dequeued but not yet marked SERVING - a cook is taking it right now
+			return
+		}
+		order.QueuePosition = &position
+
+		cooks, err := s.activeCooks(ctx, order.Stage)
+		if err != nil {
+			s.logger.Error("Failed to get cooks for order %d estimate: %v", order.ID, err)
+			return
+		}
+		if cooks == 0 {
+			return
+		}
+
+		wait, _ := scheduleLanes(s.queuedPrepTimes(stageQueue, order.ID, 0), cooks)
+		startAt := now.Add(wait)
+		completionAt := startAt.Add(s.pipeline.duration(order, s.prep))
+		order.EstimatedStartAt = &startAt
+		order.EstimatedCompletionAt = &completionAt
+
+	case domain.OrderStatusServing:
+		// ModifiedAt is set when the cook moves the order to SERVING (estimates cover its current stage)
+		startAt := order.ModifiedAt
+		completionAt := startAt.Add(s.pipeline.duration(order, s.prep))
+		order.EstimatedStartAt = &startAt
+		order.EstimatedCompletionAt = &completionAt
+	}
+}
+
+// CancelOrder cancels a pending order and removes it from the queue
+// The queue removal is the point of no return: an order a cook has already dequeued
+// (SERVING or about to be) is no longer in the queue, so the cancel is rejected
+// The order is taken out of the queue of the stage it waits for; if persisting the cancellation fails,
+// it is put back at the front of its class there
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *orderService) CancelOrder(ctx context.Context, orderID int) (*domain.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("Failed to get order %d: %v", orderID, err)
+		return nil, fmt.Errorf("%w: %v", ErrOrderNotFound, err)
+	}
+
+	if !order.IsPending() {
+		return nil, fmt.Errorf("%w: order %d is %s", ErrOrderNotCancellable, orderID, order.Status)
+	}
+
+	// Atomically take the order out of its stage queue; fails if a cook already dequeued it
+	stageQueue := s.pipeline.Queue(order.Stage)
+	queued, err := stageQueue.Remove(orderID)
+	if err != nil {
+		if errors.Is(err, queue.ErrOrderNotFound) {
+			return nil, fmt.Errorf("%w: order %d has already been taken by a cook", ErrOrderNotCancellable, orderID)
+		}
+		s.logger.Error("Failed to remove order %d from queue: %v", orderID, err)
+		return nil, fmt.Errorf("failed to remove order from queue: %w", err)
+	}
+
+	if err := s.orderRepo.UpdateStatus(ctx, orderID, domain.OrderStatusCancelled); err != nil {
+		s.logger.Error("Failed to cancel order %d: %v", orderID, err)
+		// Restore the order's place in line so it is still cooked
+		if requeueErr := stageQueue.EnqueueAtFront(queued); requeueErr != nil {
+			s.logger.Error("Failed to re-queue order %d: %v", orderID, requeueErr)
+		}
+		return nil, fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	s.logger.Info("Order %d cancelled - Queue size: %d", orderID, s.pipeline.Size())
+
+	return s.orderRepo.GetByID(ctx, orderID)
+}
+
+// RequeueOrder puts a FAILED order back in line (at the end of its stage's queue, attempts reset)
+// Shift managers can move it up with the queue admin endpoints afterwards
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *orderService) RequeueOrder(ctx context.Context, orderID int) (*domain.Order, error) {
+	s.requeueMu.Lock()
+	defer s.requeueMu.Unlock()
+
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		s.logger.Error("Failed to get order %d: %v", orderID, err)
+		return nil, fmt.Errorf("%w: %v", ErrOrderNotFound, err)
+	}
+
+	if !order.IsFailed() {
+		return nil, fmt.Errorf("%w: order %d is %s", ErrOrderNotFailed, orderID, order.Status)
+	}
+
+	if err := s.orderRepo.ResetAttempts(ctx, orderID); err != nil {
+		s.logger.Error("Failed to reset attempts of order %d: %v", orderID, err)
+		return nil, fmt.Errorf("failed to reset attempts: %w", err)
+	}
+	if err := s.orderRepo.UpdateStatus(ctx, orderID, domain.OrderStatusPending); err != nil {
+		s.logger.Error("Failed to reset order %d to PENDING: %v", orderID, err)
+		return nil, fmt.Errorf("failed to requeue order: %w", err)
+	}
+	order.Status = domain.OrderStatusPending
+	order.Attempts = 0
+
+	// Customer role and foods route the order to its class and to capable cooks
+	if err := s.loadCustomer(ctx, order, make(map[int]*domain.User)); err != nil {
+		s.logger.Error("Failed to get customer %d for order %d: %v", order.OrderedBy, orderID, err)
+	}
+	if err := loadOrderFoods(ctx, s.orderRepo, order); err != nil {
+		s.logger.Error("Failed to get foods for order %d: %v", orderID, err)
+	}
+
+	if err := s.pipeline.Queue(order.Stage).Enqueue(order); err != nil {
+		s.logger.Error("Failed to requeue order %d: %v", orderID, err)
+		return nil, fmt.Errorf("failed to enqueue order: %w", err)
+	}
+
+	s.logger.Info("Order %d REQUEUED after failing - Queue size: %d", orderID, s.pipeline.Queue(order.Stage).Size())
+
+	return s.orderRepo.GetByID(ctx, orderID)
 }
 
 // GetOrderStats retrieves order statistics
 // Time Complexity: O(n) - must scan all orders
-func (s *orderService) GetOrderStats(ctx context.Context) (completed, incomplete int, err error) {
-	completed, incomplete, err = s.orderRepo.GetStats(ctx)
+func (s *orderService) GetOrderStats(ctx context.Context) (domain.OrderStats, error) {
+	stats, err := s.orderRepo.GetStats(ctx)
 	if err != nil {
 		s.logger.Error("Failed to get order stats: %v", err)
-		return 0, 0, err
+		return domain.OrderStats{}, err
 	}
 
-	return completed, incomplete, nil
+	stats.Recovered = int(s.recovered.Load())
+	return stats, nil
 }
 
-// GetQueueSize returns the current queue size
-// Time Complexity: O(1)
+// GetQueueSize returns the number of orders waiting in every stage queue
+// Time Complexity: O(s) where s is the number of stages
 func (s *orderService) GetQueueSize() int {
-	return s.orderQueue.Size()
+	return s.pipeline.Size()
+}
+
+// GetStageQueueSize returns the number of orders waiting for one pipeline stage
+// Unknown and empty stage names map to the intake queue
+// Time Complexity: O(1)
+func (s *orderService) GetStageQueueSize(stage string) int {
+	return s.pipeline.Queue(stage).Size()
+}
+
+// RestoreQueue reloads unfinished orders from the repository into the queue
+// Used on startup in database mode: the in-process queue starts empty, so PENDING orders
+// would otherwise never be cooked. Orders a previous process left SERVING have no live
+// worker any more and are reset to PENDING (cook unassigned) before being re-queued.
+// Orders are enqueued in created_at order with their customer role enriched, so each
+// priority class is rebuilt in its original FIFO order.
+// Time Complexity: O(n log n) where n is the number of unfinished orders
+func (s *orderService) RestoreQueue(ctx context.Context) (int, error) {
+	pending, err := s.orderRepo.GetPendingOrders(ctx)
+	if err != nil {
+		s.logger.Error("Failed to get pending orders: %v", err)
+		return 0, fmt.Errorf("failed to get pending orders: %w", err)
+	}
+
+	serving, err := s.orderRepo.GetByStatus(ctx, domain.OrderStatusServing)
+	if err != nil {
+		s.logger.Error("Failed to get serving orders: %v", err)
+		return 0, fmt.Errorf("failed to get serving orders: %w", err)
+	}
+
+	// Reset orders abandoned mid-preparation back to PENDING
+	for _, order := range serving {
+		if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusPending); err != nil {
+			s.logger.Error("Failed to reset order %d to PENDING: %v", order.ID, err)
+			continue
+		}
+		if err := s.orderRepo.UnassignCook(ctx, order.ID); err != nil {
+			s.logger.Error("Failed to unassign cook from order %d: %v", order.ID, err)
+		}
+		order.Status = domain.OrderStatusPending
+		order.AssignedCookUser = nil
+		pending = append(pending, order)
+
+		s.logger.Info("Order %d reset from SERVING to PENDING", order.ID)
+	}
+
+	sort.SliceStable(pending, func(i, j int) bool {
+		if !pending[i].CreatedAt.Equal(pending[j].CreatedAt) {
+			return pending[i].CreatedAt.Before(pending[j].CreatedAt)
+		}
+		return pending[i].ID < pending[j].ID
+	})
+
+	restored := 0
+	customers := make(map[int]*domain.User) // Cache lookups: customers often have several orders
+	for _, order := range pending {
+		if err := s.loadCustomer(ctx, order, customers); err != nil {
+			s.logger.Error("Failed to get customer %d for order %d: %v", order.OrderedBy, order.ID, err)
+			continue
+		}
+
+		if err := loadOrderFoods(ctx, s.orderRepo, order); err != nil {
+			s.logger.Error("Failed to get foods for order %d: %v", order.ID, err)
+			continue
+		}
+
+		// Orders resume at the stage they reached
+		if err := s.pipeline.Queue(order.Stage).Enqueue(order); err != nil {
+			s.logger.Error("Failed to restore order %d to queue: %v", order.ID, err)
+			continue
+		}
+		restored++
+	}
+
+	s.logger.Info("Restored %d orders to queue (%d reset from SERVING) - Queue size: %d",
+		restored, len(serving), s.orderQueue.Size())
+
+	return restored, nil
+}
+
+// loadCustomer sets the customer name and role (which route an order to its priority class) if missing
+// Time Complexity: O(1) when cached, otherwise one user lookup
+func (s *orderService) loadCustomer(ctx context.Context, order *domain.Order, customers map[int]*domain.User) error {
+	if order.CustomerRole != "" {
+		return nil
+	}
+
+	customer, cached := customers[order.OrderedBy]
+	if !cached {
+		var err error
+		if customer, err = s.userRepo.GetByID(ctx, order.OrderedBy); err != nil {
+			return err
+		}
+		customers[order.OrderedBy] = customer
+	}
+
+	order.CustomerName = customer.Name
+	order.CustomerRole = customer.Role
+	return nil
+}
+
+// servedManually checks if an order's cook completes orders by hand (no timer, so no expected finish)
+// Time Complexity: O(1) when cached, otherwise one user lookup
+func (s *orderService) servedManually(ctx context.Context, order *domain.Order, cooks map[int]*domain.User) bool {
+	if order.AssignedCookUser == nil {
+		return false
+	}
+
+	cook, cached := cooks[*order.AssignedCookUser]
+	if !cached {
+		var err error
+		if cook, err = s.userRepo.GetByID(ctx, *order.AssignedCookUser); err != nil {
+			return false
+		}
+		cooks[cook.ID] = cook
+	}
+	return cook.CompletesManually()
+}
+
+// RecoverStuckOrders returns SERVING orders no cook is working on to the queue front
+// Exempt orders:
+//   - Held by a cook of this process (CookService.IsServing): the cook is cooking it, recording a failed attempt,
+//     paused with it or waiting for staff to complete it, and hands it on or back itself however long that takes
+//   - Of a manual cook when holds cannot be seen (no cook service) or the cook may be on another instance
+//     (shared queue): staff complete it whenever they do, so elapsed time says nothing
+//   - Within multiple times their expected cook time: a cook on another instance may still hold it, and an
+//     order being taken is SERVING a moment before it is held
+//
+// Every other order has no live cook and is recovered once past the bound
+// Time Complexity: O(n) where n is the number of SERVING orders
+func (s *orderService) RecoverStuckOrders(ctx context.Context, multiple int) (int, error) {
+	serving, err := s.orderRepo.GetByStatus(ctx, domain.OrderStatusServing)
+	if err != nil {
+		s.logger.Error("Failed to get serving orders: %v", err)
+		return 0, fmt.Errorf("failed to get serving orders: %w", err)
+	}
+
+	holder, tracked := s.cooks.(orderHolder)
+	_, shared := s.orderQueue.(orphanRequeuer)
+
+	now := time.Now()
+	recovered := s.requeueOrphans(ctx, time.Duration(multiple)*s.servingDuration)
+	customers := make(map[int]*domain.User)
+	cooks := make(map[int]*domain.User)
+	manualSkipped := 0
+	for _, order := range serving {
+		if tracked && holder.IsServing(order.ID) {
+			continue
+		}
+		if s.servedManually(ctx, order, cooks) && (!tracked || shared) {
+			manualSkipped++
+			continue
+		}
+
+		if err := loadOrderFoods(ctx, s.orderRepo, order); err != nil {
+			s.logger.Error("Failed to get foods for order %d: %v", order.ID, err)
+			continue
+		}
+
+		expected := s.pipeline.duration(order, s.prep)
+		servingFor := now.Sub(order.ModifiedAt)
+		if servingFor <= time.Duration(multiple)*expected {
+			continue
+		}
+
+		if err := s.loadCustomer(ctx, order, customers); err != nil {
+			s.logger.Error("Failed to get customer %d for order %d: %v", order.OrderedBy, order.ID, err)
+			continue
+		}
+
+		// Same steps as a removed cook: back to PENDING, unassigned, at the front of its stage's queue
+		cookID := order.AssignedCookUser
+		if err := s.orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusPending); err != nil {
+			s.logger.Error("Failed to reset order %d to PENDING: %v", order.ID, err)
+			continue
+		}
+		if err := s.orderRepo.UnassignCook(ctx, order.ID); err != nil {
+			s.logger.Error("Failed to unassign cook from order %d: %v", order.ID, err)
+		}
+		if err := s.pipeline.Queue(order.Stage).EnqueueAtFront(order); err != nil {
+			s.logger.Error("Failed to re-enqueue recovered order %d: %v", order.ID, err)
+			continue
+		}
+
+		recovered++
+		s.recovered.Add(1)
+		if cookID != nil {
+			s.logger.Info("Order %d RECOVERED - SERVING for %v by cook %d (expected %v) - Returned to queue front",
+				order.ID, servingFor.Round(time.Millisecond), *cookID, expected)
+		} else {
+			s.logger.Info("Order %d RECOVERED - SERVING for %v (expected %v) - Returned to queue front",
+				order.ID, servingFor.Round(time.Millisecond), expected)
+		}
+	}
+
+	if manualSkipped > 0 {
+		s.logger.Info("Stuck-order sweep left %d orders of manual cooks SERVING - their cooks may still complete them", manualSkipped)
+	}
+
+	return recovered, nil
+}
+
+// orphanRequeuer is implemented by queues shared between instances (postgres), where an instance that stops
+// between updating an order and enqueuing it leaves the order PENDING outside the queue
+// In-process queues die with their process instead and are rebuilt by RestoreQueue
+type orphanRequeuer interface {
+	RequeueOrphans(ctx context.Context, grace time.Duration) (int, error)
+}
+
+// requeueOrphans requeues PENDING orders left outside a shared queue for longer than grace, returning how many
+// Time Complexity: O(n + k log n) for a shared queue where k is the number of orphans, O(1) otherwise
+func (s *orderService) requeueOrphans(ctx context.Context, grace time.Duration) int {
+	requeuer, shared := s.orderQueue.(orphanRequeuer)
+	if !shared {
+		return 0
+	}
+
+	requeued, err := requeuer.RequeueOrphans(ctx, grace)
+	if err != nil {
+		s.logger.Error("Failed to requeue orphaned PENDING orders: %v", err)
+	}
+	if requeued > 0 {
+		s.recovered.Add(int64(requeued))
+		s.logger.Info("%d orphaned PENDING orders RECOVERED - Returned to queue", requeued)
+	}
+	return requeued
+}
+
+// loadOrderFoods fills in an order's food items if the repository returned it without them
+// Queued orders need their foods so capability-aware dequeues can route them to capable cooks
+// Time Complexity: O(1) if already loaded, otherwise one enriched repository lookup
+func loadOrderFoods(ctx context.Context, orderRepo domain.OrderRepository, order *domain.Order) error {
+	if len(order.Foods) > 0 {
+		return nil
+	}
+
+	enriched, err := orderRepo.GetByID(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	order.Foods = enriched.Foods
+	return nil
 }
 
-// validateFoodIDs validates that all food IDs exist and are available
+// validateFoodIDs validates that all food IDs exist and are available, returning the food items
 // Time Complexity: O(n) where n is number of food IDs
-func (s *orderService) validateFoodIDs(ctx context.Context, foodIDs []int) error {
+func (s *orderService) validateFoodIDs(ctx context.Context, foodIDs []int) ([]domain.Food, error) {
 	if len(foodIDs) == 0 {
-		return fmt.Errorf("order must contain at least one food item")
+		return nil, fmt.Errorf("order must contain at least one food item")
 	}
 
 	// Check for duplicates
 	seen := make(map[int]bool)
 	for _, id := range foodIDs {
 		if seen[id] {
-			return fmt.Errorf("duplicate food ID in order: %d", id)
+			return nil, fmt.Errorf("duplicate food ID in order: %d", id)
 		}
 		seen[id] = true
 	}
 
 	// Validate each food ID exists and is not deleted
+	foods := make([]domain.Food, 0, len(foodIDs))
 	for _, foodID := range foodIDs {
 		food, err := s.foodRepo.GetByID(ctx, foodID)
 		if err != nil {
-			return fmt.Errorf("food item not found: %d", foodID)
+			return nil, fmt.Errorf("food item not found: %d", foodID)
 		}
 		if food.IsDeleted() {
-			return fmt.Errorf("food item is no longer available: %s", food.Name)
+			return nil, fmt.Errorf("food item is no longer available: %s", food.Name)
 		}
+		foods = append(foods, *food)
 	}
 
-	return nil
+	return foods, nil
 }
@@ -24,7 +24,7 @@ func setupOrderServiceTest(t *testing.T) (OrderService, domain.UserRepository, d
 	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
 	orderQueue := queue.NewPriorityQueue()
 
-	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, 10*time.Second)
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, OrderServiceConfig{ServingDuration: 10 * time.Second})
 
 	// Create sample food items for tests
 	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
@@ -181,6 +181,45 @@ func TestGetOrder(t *testing.T) {
 	assert.Equal(t, createdOrder.OrderedBy, retrievedOrder.OrderedBy, "Order customer should match")
 }
 
+// TestGetOrderQueueEstimate tests that a pending order reports its position and ETA
+func TestGetOrderQueueEstimate(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, _, _, _ := setupOrderServiceTest(t)
+
+	regular, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	vip, err := userRepo.Create(ctx, &domain.User{Name: "Jane Smith", Role: domain.RoleVIPCustomer})
+	require.NoError(t, err)
+
+	regularOrder, err := orderService.CreateOrder(ctx, regular.ID, []int{1})
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		_, err = orderService.CreateOrder(ctx, vip.ID, []int{1})
+		require.NoError(t, err)
+	}
+
+	// No active cooks: position only
+	order, err := orderService.GetOrder(ctx, regularOrder.ID)
+	require.NoError(t, err)
+	require.NotNil(t, order.QueuePosition)
+	assert.Equal(t, 3, *order.QueuePosition, "Both VIP orders should be counted ahead")
+	assert.Nil(t, order.EstimatedStartAt, "No ETA without active cooks")
+
+	// Two cooks: the VIP orders take the first wave, the Regular order starts one servingDuration later
+	for i := 0; i < 2; i++ {
+		_, err = userRepo.Create(ctx, &domain.User{Name: "Cook", Role: domain.RoleCook})
+		require.NoError(t, err)
+	}
+
+	before := time.Now()
+	order, err = orderService.GetOrder(ctx, regularOrder.ID)
+	require.NoError(t, err)
+	require.NotNil(t, order.EstimatedStartAt)
+	require.NotNil(t, order.EstimatedCompletionAt)
+	assert.WithinDuration(t, before.Add(10*time.Second), *order.EstimatedStartAt, time.Second)
+	assert.Equal(t, 10*time.Second, order.EstimatedCompletionAt.Sub(*order.EstimatedStartAt))
+}
+
 // TestGetOrderNonExistent tests retrieving a non-existent order
 func TestGetOrderNonExistent(t *testing.T) {
 	ctx := context.Background()
@@ -199,10 +238,10 @@ func TestGetOrderStats(t *testing.T) {
 	orderService, userRepo, _, _, _ := setupOrderServiceTest(t)
 
 	// Initially, stats should be zero
-	completed, incomplete, err := orderService.GetOrderStats(ctx)
+	stats, err := orderService.GetOrderStats(ctx)
 	require.NoError(t, err)
-	assert.Equal(t, 0, completed, "Should have no completed orders initially")
-	assert.Equal(t, 0, incomplete, "Should have no incomplete orders initially")
+	assert.Equal(t, 0, stats.Completed, "Should have no completed orders initially")
+	assert.Equal(t, 0, stats.Incomplete, "Should have no incomplete orders initially")
 
 	// Create a customer and order
 	customer, err := userRepo.Create(ctx, &domain.User{
@@ -215,10 +254,289 @@ func TestGetOrderStats(t *testing.T) {
 	require.NoError(t, err)
 
 	// Stats should now show 1 incomplete order
-	completed, incomplete, err = orderService.GetOrderStats(ctx)
+	stats, err = orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Completed, "Should have no completed orders")
+	assert.Equal(t, 1, stats.Incomplete, "Should have 1 incomplete order")
+}
+
+// TestCreateOrderSetsSLADeadline tests that the deadline comes from the customer's role SLA target
+func TestCreateOrderSetsSLADeadline(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	slaTargets := map[domain.RoleType]time.Duration{domain.RoleVIPCustomer: 3 * time.Minute}
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, queue.NewPriorityQueue(), logger.NewNoOpLogger(), OrderServiceConfig{ServingDuration: 10 * time.Second, SLATargets: slaTargets})
+
+	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+
+	vip, err := userRepo.Create(ctx, &domain.User{Name: "VIP Customer", Role: domain.RoleVIPCustomer})
+	require.NoError(t, err)
+	regular, err := userRepo.Create(ctx, &domain.User{Name: "Regular Customer", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	before := time.Now()
+	vipOrder, err := orderService.CreateOrder(ctx, vip.ID, []int{1})
+	require.NoError(t, err)
+	require.NotNil(t, vipOrder.Deadline, "VIP order should have a deadline")
+	assert.WithinDuration(t, before.Add(3*time.Minute), *vipOrder.Deadline, time.Second)
+
+	regularOrder, err := orderService.CreateOrder(ctx, regular.ID, []int{1})
+	require.NoError(t, err)
+	assert.Nil(t, regularOrder.Deadline, "Roles without an SLA target should have no deadline")
+}
+
+// TestCreateOrderRefusedWhenQueueFull tests admission control and the Retry-After estimate
+func TestCreateOrderRefusedWhenQueueFull(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := queue.NewPriorityQueue(queue.WithAdmissionLimits(queue.AdmissionLimits{MaxDepth: 3}))
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, logger.NewNoOpLogger(), OrderServiceConfig{ServingDuration: 10 * time.Second})
+
+	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+		require.NoError(t, err)
+	}
+
+	// No active cooks: suggest retrying after one serving duration
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	var full *QueueFullError
+	require.ErrorAs(t, err, &full, "Fourth order should be refused")
+	assert.ErrorIs(t, err, queue.ErrQueueFull)
+	assert.Equal(t, 10*time.Second, full.RetryAfter)
+
+	orders, err := orderRepo.GetByCustomerID(ctx, customer.ID)
+	require.NoError(t, err)
+	assert.Len(t, orders, 3, "Refused order should not be persisted")
+}
+
+// cookRosterStub lists fixed cooks with their worker states
+type cookRosterStub []*domain.User
+
+// GetAllCooks returns the listed cooks
+func (r cookRosterStub) GetAllCooks(ctx context.Context, includeDeleted bool) ([]*domain.User, error) {
+	return r, nil
+}
+
+// TestRetryAfterCountsOnlyRunningTimerCooks tests that paused, manual and other-stage cooks add no throughput
+func TestRetryAfterCountsOnlyRunningTimerCooks(t *testing.T) {
+	intake := queue.NewPriorityQueue()
+	pipeline, err := NewPipeline([]domain.PipelineStage{{Name: "prep"}, {Name: "pack"}}, intake, newStageQueue)
+	require.NoError(t, err)
+
+	roster := cookRosterStub{
+		{ID: 1, Role: domain.RoleCook, WorkerState: domain.WorkerStateRunning},
+		{ID: 2, Role: domain.RoleCook, WorkerState: domain.WorkerStateRunning, Stage: "prep"},
+		{ID: 3, Role: domain.RoleCook, WorkerState: domain.WorkerStatePaused},
+		{ID: 4, Role: domain.RoleCook, WorkerState: domain.WorkerStateStopped},
+		{ID: 5, Role: domain.RoleCook, WorkerState: domain.WorkerStateStopped, CompletionMode: domain.CompletionModeManual},
+		{ID: 6, Role: domain.RoleCook, WorkerState: domain.WorkerStateRunning, Stage: "pack"},
+	}
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	svc := NewOrderService(memory.NewOrderRepository(userRepo, foodRepo), userRepo, foodRepo, intake, logger.NewNoOpLogger(), OrderServiceConfig{
+		ServingDuration: 10 * time.Second,
+		Pipeline:        pipeline,
+		Cooks:           roster,
+	}).(*orderService)
+
+	active, err := svc.activeCooks(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, active, "Only running timer cooks of the intake stage should count")
+
+	// 4 orders to drain with 2 cooks = 2 waves of 10s
+	assert.Equal(t, 20*time.Second, svc.retryAfter(context.Background(), 4))
+}
+
+// racedAdmitQueue is a queue whose Admit pre-check always passes, as if concurrent orders filled it afterwards
+type racedAdmitQueue struct {
+	queue.OrderQueue
+}
+
+// Admit lets every order through to AdmitAndEnqueue
+func (q *racedAdmitQueue) Admit(order *domain.Order) error {
+	return nil
+}
+
+// TestCreateOrderRefusedAtEnqueue tests that an order admitted by the pre-check is refused and cancelled
+// when the queue filled up before it was enqueued
+func TestCreateOrderRefusedAtEnqueue(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := &racedAdmitQueue{OrderQueue: queue.NewPriorityQueue(queue.WithAdmissionLimits(queue.AdmissionLimits{MaxDepth: 1}))}
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, logger.NewNoOpLogger(), OrderServiceConfig{ServingDuration: 10 * time.Second})
+
+	foodRepo.Create(ctx, &domain.Food{Name: "Burger", Type: domain.FoodTypeFood})
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1})
+	var full *QueueFullError
+	require.ErrorAs(t, err, &full, "Second order should be refused at enqueue")
+	assert.Equal(t, 1, orderQueue.Size(), "Refused order should not be queued")
+
+	cancelled, err := orderRepo.GetByStatus(ctx, domain.OrderStatusCancelled)
+	require.NoError(t, err)
+	assert.Empty(t, cancelled, "Refused order should not show up as a cancellation")
+
+	orders, err := orderRepo.GetByCustomerID(ctx, customer.ID)
+	require.NoError(t, err)
+	assert.Len(t, orders, 1, "Refused order should be deleted from history")
+
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Incomplete, "Refused order should not count in stats")
+}
+
+// TestCreateOrderRefusedWhenOnlySpecialistsCannotPrepareIt tests that a mixed order no station can make whole is refused
+func TestCreateOrderRefusedWhenOnlySpecialistsCannotPrepareIt(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, _, orderRepo, orderQueue := setupOrderServiceTest(t)
+
+	for _, station := range []domain.FoodType{domain.FoodTypeFood, domain.FoodTypeDrink} {
+		_, err := userRepo.Create(ctx, &domain.User{Name: "Station", Role: domain.RoleCook, Capabilities: []domain.FoodType{station}})
+		require.NoError(t, err)
+	}
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+
+	// Burger and Soda: food and drink stations can each make only part of it
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1, 3})
+	assert.ErrorIs(t, err, ErrNoCapableCook)
+	assert.Equal(t, 0, orderQueue.Size(), "Refused order should not be queued")
+	orders, err := orderRepo.GetByCustomerID(ctx, customer.ID)
+	require.NoError(t, err)
+	assert.Empty(t, orders, "Refused order should not be persisted")
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1, 2})
+	require.NoError(t, err, "Order the food station can make whole should be accepted")
+
+	// A generalist can make the mixed order
+	_, err = userRepo.Create(ctx, &domain.User{Name: "Line Cook", Role: domain.RoleCook})
+	require.NoError(t, err)
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{1, 3})
+	require.NoError(t, err)
+}
+
+// TestRetryAfterUsesCookThroughput tests that the Retry-After hint scales with active cooks
+func TestRetryAfterUsesCookThroughput(t *testing.T) {
+	ctx := context.Background()
+	service, userRepo, _, _, _ := setupOrderServiceTest(t)
+	svc := service.(*orderService)
+
+	for i := 0; i < 2; i++ {
+		_, err := userRepo.Create(ctx, &domain.User{Name: "Cook Bot", Role: domain.RoleCook})
+		require.NoError(t, err)
+	}
+
+	// 3 orders to drain with 2 cooks = 2 waves of 10s
+	assert.Equal(t, 20*time.Second, svc.retryAfter(ctx, 3))
+	assert.Equal(t, 10*time.Second, svc.retryAfter(ctx, 1))
+}
+
+// TestCancelOrder tests cancelling a pending order
+func TestCancelOrder(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, _, _, orderQueue := setupOrderServiceTest(t)
+
+	customer, err := userRepo.Create(ctx, &domain.User{
+		Name: "John Doe",
+		Role: domain.RoleRegularCustomer,
+	})
+	require.NoError(t, err)
+
+	order, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	cancelled, err := orderService.CancelOrder(ctx, order.ID)
+	require.NoError(t, err, "Should cancel pending order")
+	assert.Equal(t, domain.OrderStatusCancelled, cancelled.Status, "Order status should be CANCELLED")
+	assert.Equal(t, 0, orderQueue.Size(), "Order should be removed from queue")
+
+	// Cancelled orders are neither completed nor incomplete
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, stats.Completed)
+	assert.Equal(t, 0, stats.Incomplete)
+
+	// Cancelling twice is rejected
+	_, err = orderService.CancelOrder(ctx, order.ID)
+	assert.ErrorIs(t, err, ErrOrderNotCancellable)
+}
+
+// TestCancelOrderTakenByCook tests that an order already dequeued by a cook cannot be cancelled
+func TestCancelOrderTakenByCook(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, _, orderRepo, orderQueue := setupOrderServiceTest(t)
+
+	customer, err := userRepo.Create(ctx, &domain.User{
+		Name: "John Doe",
+		Role: domain.RoleRegularCustomer,
+	})
+	require.NoError(t, err)
+
+	order, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	// Cook has dequeued the order but not yet marked it SERVING
+	_, err = orderQueue.Dequeue()
+	require.NoError(t, err)
+
+	_, err = orderService.CancelOrder(ctx, order.ID)
+	assert.ErrorIs(t, err, ErrOrderNotCancellable, "In-flight order should not be cancellable")
+
+	// Once SERVING the status check rejects it as well
+	require.NoError(t, orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusServing))
+	_, err = orderService.CancelOrder(ctx, order.ID)
+	assert.ErrorIs(t, err, ErrOrderNotCancellable, "SERVING order should not be cancellable")
+
+	stored, err := orderRepo.GetByID(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusServing, stored.Status, "Rejected cancel must not change status")
+}
+
+// TestCancelOrderNonExistent tests cancelling an order that does not exist
+func TestCancelOrderNonExistent(t *testing.T) {
+	ctx := context.Background()
+	orderService, _, _, _, _ := setupOrderServiceTest(t)
+
+	_, err := orderService.CancelOrder(ctx, 99999)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+// TestCancelOrderWaitingForLaterStage tests cancelling an order waiting in the queue of a later pipeline stage
+func TestCancelOrderWaitingForLaterStage(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	intake := queue.NewPriorityQueue()
+	pipeline, err := NewPipeline([]domain.PipelineStage{{Name: "prep"}, {Name: "pack"}}, intake, newStageQueue)
 	require.NoError(t, err)
-	assert.Equal(t, 0, completed, "Should have no completed orders")
-	assert.Equal(t, 1, incomplete, "Should have 1 incomplete order")
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, intake, logger.NewNoOpLogger(), OrderServiceConfig{ServingDuration: 10 * time.Second, Pipeline: pipeline})
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	order, err := orderRepo.Create(ctx, &domain.Order{Status: domain.OrderStatusPending, OrderedBy: customer.ID, Stage: "pack"}, nil)
+	require.NoError(t, err)
+	require.NoError(t, pipeline.Queue("pack").Enqueue(order))
+
+	cancelled, err := orderService.CancelOrder(ctx, order.ID)
+	require.NoError(t, err, "Order waiting for a later stage should be cancellable")
+	assert.Equal(t, domain.OrderStatusCancelled, cancelled.Status)
+	assert.Equal(t, 0, pipeline.Queue("pack").Size(), "Order should be removed from its stage queue")
 }
 
 // TestGetQueueSize tests retrieving the queue size
@@ -243,6 +561,40 @@ func TestGetQueueSize(t *testing.T) {
 	assert.Equal(t, 1, orderService.GetQueueSize(), "Queue should have 1 order")
 }
 
+// TestQueueReadsCoverLaterStages tests that queue size and position include orders waiting for a later stage
+func TestQueueReadsCoverLaterStages(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	intake := queue.NewPriorityQueue()
+	pipeline, err := NewPipeline([]domain.PipelineStage{{Name: "prep"}, {Name: "pack", Duration: 5 * time.Second}}, intake, newStageQueue)
+	require.NoError(t, err)
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, intake, logger.NewNoOpLogger(), OrderServiceConfig{ServingDuration: 10 * time.Second, Pipeline: pipeline})
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{Name: "Cook Bot", Role: domain.RoleCook, Stage: "pack"})
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		order, err := orderRepo.Create(ctx, &domain.Order{Status: domain.OrderStatusPending, OrderedBy: customer.ID, Stage: "pack"}, nil)
+		require.NoError(t, err)
+		require.NoError(t, pipeline.Queue("pack").Enqueue(order))
+	}
+
+	assert.Equal(t, 2, orderService.GetQueueSize(), "Orders waiting for a later stage should count")
+	assert.Equal(t, 0, orderService.GetStageQueueSize(""), "Intake queue should be empty")
+	assert.Equal(t, 2, orderService.GetStageQueueSize("pack"))
+
+	second, err := orderService.GetOrder(ctx, 2)
+	require.NoError(t, err)
+	require.NotNil(t, second.QueuePosition)
+	assert.Equal(t, 2, *second.QueuePosition, "Position should be within the stage queue")
+	require.NotNil(t, second.EstimatedStartAt)
+	assert.WithinDuration(t, time.Now().Add(5*time.Second), *second.EstimatedStartAt, time.Second, "Wait should use the stage duration")
+}
+
 // TestMultipleOrders tests creating multiple orders
 func TestMultipleOrders(t *testing.T) {
 	ctx := context.Background()
@@ -274,13 +626,265 @@ func TestMultipleOrders(t *testing.T) {
 	// Verify queue size and stats
 	assert.Equal(t, 3, orderService.GetQueueSize(), "Queue should have 3 orders")
 
-	completed, incomplete, err := orderService.GetOrderStats(ctx)
+	stats, err := orderService.GetOrderStats(ctx)
 	require.NoError(t, err)
-	assert.Equal(t, 0, completed, "Should have no completed orders")
-	assert.Equal(t, 3, incomplete, "Should have 3 incomplete orders")
+	assert.Equal(t, 0, stats.Completed, "Should have no completed orders")
+	assert.Equal(t, 3, stats.Incomplete, "Should have 3 incomplete orders")
 
 	// VIP order should be at the front of the queue
 	peekedOrder, err := orderQueue.Peek()
 	require.NoError(t, err)
 	assert.Equal(t, domain.RoleVIPCustomer, peekedOrder.CustomerRole, "VIP order should be first in queue")
 }
+
+// TestRestoreQueue tests rehydrating the queue from persisted PENDING and SERVING orders
+func TestRestoreQueue(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, _, orderRepo, orderQueue := setupOrderServiceTest(t)
+
+	regular, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	vip, err := userRepo.Create(ctx, &domain.User{Name: "Jane Smith", Role: domain.RoleVIPCustomer})
+	require.NoError(t, err)
+	cook, err := userRepo.Create(ctx, &domain.User{Name: "Cook", Role: domain.RoleCook})
+	require.NoError(t, err)
+
+	// Orders persisted by a previous process (not in this process's queue)
+	persist := func(customerID int, status domain.OrderStatus) *domain.Order {
+		order, err := orderRepo.Create(ctx, &domain.Order{Status: status, OrderedBy: customerID}, []int{1})
+		require.NoError(t, err)
+		return order
+	}
+	servingRegular := persist(regular.ID, domain.OrderStatusServing)
+	require.NoError(t, orderRepo.AssignCook(ctx, servingRegular.ID, cook.ID))
+	pendingRegular := persist(regular.ID, domain.OrderStatusPending)
+	pendingVIP := persist(vip.ID, domain.OrderStatusPending)
+	persist(vip.ID, domain.OrderStatusComplete)
+
+	restored, err := orderService.RestoreQueue(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, restored, "PENDING and SERVING orders should be restored")
+	assert.Equal(t, 3, orderQueue.Size())
+
+	// VIP first, then Regular orders in created_at order
+	for _, expectedID := range []int{pendingVIP.ID, servingRegular.ID, pendingRegular.ID} {
+		order, err := orderQueue.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, order.ID)
+	}
+
+	reset, err := orderRepo.GetByID(ctx, servingRegular.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPending, reset.Status, "SERVING order should be reset to PENDING")
+	assert.Nil(t, reset.AssignedCookUser, "Reset order should have no cook assigned")
+}
+
+// TestRecoverStuckOrders tests that only orders SERVING past multiple x their cook time are requeued at the front
+func TestRecoverStuckOrders(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, foodRepo, orderRepo, orderQueue := setupOrderServiceTest(t)
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	cook, err := userRepo.Create(ctx, &domain.User{Name: "Cook", Role: domain.RoleCook})
+	require.NoError(t, err)
+	water, err := foodRepo.Create(ctx, &domain.Food{Name: "Water", Type: domain.FoodTypeDrink, PrepTimeMs: 1})
+	require.NoError(t, err)
+
+	// Orders a crashed cook left SERVING: a 1ms water and a 10s burger
+	serve := func(foodID int) *domain.Order {
+		order, err := orderRepo.Create(ctx, &domain.Order{Status: domain.OrderStatusServing, OrderedBy: customer.ID}, []int{foodID})
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.AssignCook(ctx, order.ID, cook.ID))
+		require.NoError(t, orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusServing))
+		return order
+	}
+	stuck := serve(water.ID)
+	cooking := serve(1)
+
+	queued, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	time.Sleep(10 * time.Millisecond)
+
+	recovered, err := orderService.RecoverStuckOrders(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, recovered, "Only the order far past its cook time should be recovered")
+
+	next, err := orderQueue.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, stuck.ID, next.ID, "Recovered order should go to the front of the queue")
+	next, err = orderQueue.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, queued.ID, next.ID)
+
+	reset, err := orderRepo.GetByID(ctx, stuck.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPending, reset.Status)
+	assert.Nil(t, reset.AssignedCookUser, "Recovered order should have no cook assigned")
+
+	stillCooking, err := orderRepo.GetByID(ctx, cooking.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusServing, stillCooking.Status, "Order within its cook time should be left alone")
+
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, stats.Recovered, "Recoveries should be counted in the stats")
+}
+
+// TestRecoverStuckOrdersSkipsManualCooks tests that orders waiting for a manual cook are never treated as stuck
+func TestRecoverStuckOrdersSkipsManualCooks(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, foodRepo, orderRepo, orderQueue := setupOrderServiceTest(t)
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	cook, err := userRepo.Create(ctx, &domain.User{Name: "Tablet Cook", Role: domain.RoleCook, CompletionMode: domain.CompletionModeManual})
+	require.NoError(t, err)
+	water, err := foodRepo.Create(ctx, &domain.Food{Name: "Water", Type: domain.FoodTypeDrink, PrepTimeMs: 1})
+	require.NoError(t, err)
+
+	order, err := orderRepo.Create(ctx, &domain.Order{Status: domain.OrderStatusServing, OrderedBy: customer.ID}, []int{water.ID})
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.AssignCook(ctx, order.ID, cook.ID))
+	require.NoError(t, orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusServing))
+
+	time.Sleep(10 * time.Millisecond)
+
+	recovered, err := orderService.RecoverStuckOrders(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 0, recovered, "Manual cooks take as long as they take")
+	assert.True(t, orderQueue.IsEmpty())
+}
+
+// heldRosterStub is a cook roster that knows which orders its cooks hold
+type heldRosterStub struct {
+	cookRosterStub
+	held map[int]bool // Order IDs held by a cook
+}
+
+// IsServing reports the configured holds
+func (r heldRosterStub) IsServing(orderID int) bool {
+	return r.held[orderID]
+}
+
+// TestRecoverStuckOrdersUsesCookLiveness tests that held orders are never recovered, however late,
+// and that a manual order nobody holds is recovered once holds can be seen
+func TestRecoverStuckOrdersUsesCookLiveness(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderQueue := queue.NewPriorityQueue()
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	bot, err := userRepo.Create(ctx, &domain.User{Name: "Cook Bot", Role: domain.RoleCook})
+	require.NoError(t, err)
+	staff, err := userRepo.Create(ctx, &domain.User{Name: "Tablet Cook", Role: domain.RoleCook, CompletionMode: domain.CompletionModeManual})
+	require.NoError(t, err)
+	water, err := foodRepo.Create(ctx, &domain.Food{Name: "Water", Type: domain.FoodTypeDrink, PrepTimeMs: 1})
+	require.NoError(t, err)
+
+	serve := func(cookID int) *domain.Order {
+		order, err := orderRepo.Create(ctx, &domain.Order{Status: domain.OrderStatusServing, OrderedBy: customer.ID}, []int{water.ID})
+		require.NoError(t, err)
+		require.NoError(t, orderRepo.AssignCook(ctx, order.ID, cookID))
+		return order
+	}
+	retrying := serve(bot.ID) // Held: e.g. a paused cook or a failed attempt being recorded
+	awaiting := serve(staff.ID)
+	orphaned := serve(staff.ID)
+
+	roster := heldRosterStub{held: map[int]bool{retrying.ID: true, awaiting.ID: true}}
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, logger.NewNoOpLogger(), OrderServiceConfig{
+		ServingDuration: 10 * time.Second,
+		Cooks:           roster,
+	})
+
+	time.Sleep(10 * time.Millisecond)
+
+	recovered, err := orderService.RecoverStuckOrders(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, recovered, "Only the order no cook holds should be recovered")
+
+	next, err := orderQueue.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, orphaned.ID, next.ID, "Manual order nobody holds should be recovered")
+
+	for _, held := range []*domain.Order{retrying, awaiting} {
+		order, err := orderRepo.GetByID(ctx, held.ID)
+		require.NoError(t, err)
+		assert.Equal(t, domain.OrderStatusServing, order.Status, "Held order %d should be left alone", held.ID)
+	}
+}
+
+// TestRequeueOrder tests that only FAILED orders can be requeued and that their attempts are reset
+func TestRequeueOrder(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, _, orderRepo, orderQueue := setupOrderServiceTest(t)
+
+	customer, err := userRepo.Create(ctx, &domain.User{
+		Name: "John Doe",
+		Role: domain.RoleRegularCustomer,
+	})
+	require.NoError(t, err)
+
+	order, err := orderService.CreateOrder(ctx, customer.ID, []int{1})
+	require.NoError(t, err)
+
+	_, err = orderService.RequeueOrder(ctx, order.ID)
+	assert.ErrorIs(t, err, ErrOrderNotFailed, "PENDING order should not be requeued")
+
+	// Simulate a cook failing the order until it ran out of attempts
+	_, err = orderQueue.Dequeue()
+	require.NoError(t, err)
+	_, err = orderRepo.IncrementAttempts(ctx, order.ID)
+	require.NoError(t, err)
+	require.NoError(t, orderRepo.UpdateStatus(ctx, order.ID, domain.OrderStatusFailed))
+
+	requeued, err := orderService.RequeueOrder(ctx, order.ID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPending, requeued.Status)
+	assert.Equal(t, 0, requeued.Attempts, "Attempts should be reset")
+	assert.Equal(t, 1, orderQueue.Size(), "Order should be back in the queue")
+
+	_, err = orderService.RequeueOrder(ctx, order.ID)
+	assert.ErrorIs(t, err, ErrOrderNotFailed, "Requeued order should not be requeued twice")
+
+	_, err = orderService.RequeueOrder(ctx, 999)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+// sharedQueueStub is an in-process queue standing in for a shared one that finds orphaned orders
+type sharedQueueStub struct {
+	queue.OrderQueue
+	orphans int           // Orders RequeueOrphans reports
+	grace   time.Duration // Grace RequeueOrphans was called with
+}
+
+// RequeueOrphans records the grace period and reports the configured orphans
+func (q *sharedQueueStub) RequeueOrphans(ctx context.Context, grace time.Duration) (int, error) {
+	q.grace = grace
+	return q.orphans, nil
+}
+
+// TestRecoverStuckOrdersRequeuesOrphans tests that a shared queue's orphaned PENDING orders are requeued by the sweep
+func TestRecoverStuckOrdersRequeuesOrphans(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	sharedQueue := &sharedQueueStub{OrderQueue: queue.NewPriorityQueue(), orphans: 2}
+
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, sharedQueue, logger.NewNoOpLogger(), OrderServiceConfig{ServingDuration: 10 * time.Second})
+
+	recovered, err := orderService.RecoverStuckOrders(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, recovered, "Orphaned orders should count as recovered")
+	assert.Equal(t, 30*time.Second, sharedQueue.grace, "Orphans get the same bound as stuck orders")
+
+	stats, err := orderService.GetOrderStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Recovered)
+}
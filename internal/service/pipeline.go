@@ -0,0 +1,170 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/pkg/queue"
+)
+
+// Pipeline is the ordered list of stages an order passes through (e.g. prep, cook, pack)
+// Every stage has its own queue and its own cooks; the first stage is served from the intake queue
+// orders are placed in. Without stages the kitchen has a single unnamed stage (no pipeline).
+// Following Open/Closed Principle: stages are configuration, cooks serve whichever stage they work
+type Pipeline struct {
+	stages []domain.PipelineStage
+	queues map[string]queue.OrderQueue // Stage name -> its queue (the first stage maps to intake)
+	intake queue.OrderQueue
+}
+
+// NewPipeline creates a pipeline over stages, in order
+// intake is the first stage's queue; newQueue creates the queue of every later stage
+// Following Dependency Injection pattern: queue construction is left to the caller
+// Time Complexity: O(s) where s is the number of stages
+func NewPipeline(stages []domain.PipelineStage, intake queue.OrderQueue, newQueue func() (queue.OrderQueue, error)) (*Pipeline, error) {
+	p := &Pipeline{
+		stages: stages,
+		queues: make(map[string]queue.OrderQueue, len(stages)),
+		intake: intake,
+	}
+
+	for i, stage := range stages {
+		if _, exists := p.queues[stage.Name]; exists || stage.Name == "" {
+			return nil, fmt.Errorf("invalid pipeline stage %q: names must be unique and not empty", stage.Name)
+		}
+		if i == 0 {
+			p.queues[stage.Name] = intake
+			continue
+		}
+
+		stageQueue, err := newQueue()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create queue for stage %s: %w", stage.Name, err)
+		}
+		p.queues[stage.Name] = stageQueue
+	}
+
+	return p, nil
+}
+
+// singleStagePipeline returns the pipeline of a kitchen without stages
+// Time Complexity: O(1)
+func singleStagePipeline(intake queue.OrderQueue) *Pipeline {
+	return &Pipeline{queues: map[string]queue.OrderQueue{}, intake: intake}
+}
+
+// Enabled reports whether stages are configured
+// Time Complexity: O(1)
+func (p *Pipeline) Enabled() bool {
+	return len(p.stages) > 0
+}
+
+// Stages returns the configured stages, in order
+// Time Complexity: O(1)
+func (p *Pipeline) Stages() []domain.PipelineStage {
+	return p.stages
+}
+
+// First returns the name of the stage new orders start in (empty without a pipeline)
+// Time Complexity: O(1)
+func (p *Pipeline) First() string {
+	if len(p.stages) == 0 {
+		return ""
+	}
+	return p.stages[0].Name
+}
+
+// Has reports whether a stage exists (the empty name stands for the first stage)
+// Time Complexity: O(1)
+func (p *Pipeline) Has(stage string) bool {
+	_, exists := p.queues[stage]
+	return stage == "" || exists
+}
+
+// Queue returns the queue of a stage; unknown and empty names map to the intake queue
+// Time Complexity: O(1)
+func (p *Pipeline) Queue(stage string) queue.OrderQueue {
+	if stageQueue, exists := p.queues[stage]; exists {
+		return stageQueue
+	}
+	return p.intake
+}
+
+// StageQueue is a pipeline stage with the queue of orders waiting for it
+type StageQueue struct {
+	Stage string // Stage name (empty without a pipeline)
+	Queue queue.OrderQueue
+}
+
+// All returns the queue of every stage in pipeline order, the intake queue first
+// Without a pipeline this is the intake queue alone
+// Time Complexity: O(s) where s is the number of stages
+func (p *Pipeline) All() []StageQueue {
+	if len(p.stages) == 0 {
+		return []StageQueue{{Queue: p.intake}}
+	}
+
+	all := make([]StageQueue, len(p.stages))
+	for i, stage := range p.stages {
+		all[i] = StageQueue{Stage: stage.Name, Queue: p.queues[stage.Name]}
+	}
+	return all
+}
+
+// Size returns the number of orders waiting for any stage
+// Time Complexity: O(s) where s is the number of stages
+func (p *Pipeline) Size() int {
+	size := 0
+	for _, stageQueue := range p.All() {
+		size += stageQueue.Queue.Size()
+	}
+	return size
+}
+
+// Locate returns the queue a waiting order is in, falling back to the intake queue
+// (whose operations then report queue.ErrOrderNotFound)
+// Time Complexity: O(s * k) where s is the number of stages and k is the cost of Position
+func (p *Pipeline) Locate(orderID int) queue.OrderQueue {
+	for _, stageQueue := range p.All() {
+		if _, err := stageQueue.Queue.Position(orderID); err == nil {
+			return stageQueue.Queue
+		}
+	}
+	return p.intake
+}
+
+// Next returns the stage after the given one, reporting false after the last stage
+// The empty name stands for the first stage
+// Time Complexity: O(s) where s is the number of stages
+func (p *Pipeline) Next(stage string) (domain.PipelineStage, bool) {
+	stage = p.resolve(stage)
+	for i := 0; i < len(p.stages)-1; i++ {
+		if p.stages[i].Name == stage {
+			return p.stages[i+1], true
+		}
+	}
+	return domain.PipelineStage{}, false
+}
+
+// duration returns how long a cook spends on an order in its current stage
+// Stages without their own duration take as long as the order's items (prep)
+// Time Complexity: O(s + f log f) where s is stages and f is the items in the order
+func (p *Pipeline) duration(order *domain.Order, prep prepTimer) time.Duration {
+	name := p.resolve(order.Stage)
+	for _, stage := range p.stages {
+		if stage.Name == name && stage.Duration > 0 {
+			return stage.Duration
+		}
+	}
+	return prep.orderDuration(order)
+}
+
+// resolve maps the empty stage name to the first stage
+// Time Complexity: O(1)
+func (p *Pipeline) resolve(stage string) string {
+	if stage == "" {
+		return p.First()
+	}
+	return stage
+}
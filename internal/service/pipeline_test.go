@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/pkg/queue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStageQueue creates the queue of a later pipeline stage
+func newStageQueue() (queue.OrderQueue, error) {
+	return queue.NewPriorityQueue(), nil
+}
+
+// TestPipelineRoutesStagesToTheirQueues tests stage order, queue routing and stage durations
+func TestPipelineRoutesStagesToTheirQueues(t *testing.T) {
+	intake := queue.NewPriorityQueue()
+	pipeline, err := NewPipeline([]domain.PipelineStage{
+		{Name: "prep", Duration: 2 * time.Second},
+		{Name: "cook"},
+		{Name: "pack", Duration: time.Second},
+	}, intake, newStageQueue)
+	require.NoError(t, err)
+
+	assert.True(t, pipeline.Enabled())
+	assert.Equal(t, "prep", pipeline.First())
+	assert.Same(t, intake, pipeline.Queue("prep"), "First stage should be served from the intake queue")
+	assert.Same(t, intake, pipeline.Queue(""), "Empty stage should mean the first stage")
+	assert.NotSame(t, intake, pipeline.Queue("cook"))
+	assert.NotSame(t, pipeline.Queue("cook"), pipeline.Queue("pack"))
+
+	next, ok := pipeline.Next("")
+	require.True(t, ok)
+	assert.Equal(t, "cook", next.Name)
+	_, ok = pipeline.Next("pack")
+	assert.False(t, ok, "Last stage has no next stage")
+
+	assert.True(t, pipeline.Has("pack"))
+	assert.False(t, pipeline.Has("plate"))
+
+	prep := prepTimer{defaultPrepTime: 10 * time.Second}
+	order := &domain.Order{Stage: "prep", Foods: []domain.Food{{Name: "Burger"}}}
+	assert.Equal(t, 2*time.Second, pipeline.duration(order, prep), "Stage with a duration should use it")
+	order.Stage = "cook"
+	assert.Equal(t, 10*time.Second, pipeline.duration(order, prep), "Stage without a duration should take the item prep time")
+}
+
+// TestNewPipelineRejectsDuplicateStages tests that stage names must be unique
+func TestNewPipelineRejectsDuplicateStages(t *testing.T) {
+	_, err := NewPipeline([]domain.PipelineStage{{Name: "prep"}, {Name: "prep"}}, queue.NewPriorityQueue(), newStageQueue)
+	assert.Error(t, err)
+}
+
+// TestSingleStagePipeline tests that a kitchen without stages serves everything from the intake queue
+func TestSingleStagePipeline(t *testing.T) {
+	intake := queue.NewPriorityQueue()
+	pipeline := singleStagePipeline(intake)
+
+	assert.False(t, pipeline.Enabled())
+	assert.Empty(t, pipeline.First())
+	assert.Same(t, intake, pipeline.Queue(""))
+	assert.True(t, pipeline.Has(""))
+	assert.False(t, pipeline.Has("prep"))
+	_, ok := pipeline.Next("")
+	assert.False(t, ok)
+}
+
+// TestPipelineAggregatesStageQueues tests listing, sizing and locating across stage queues
+func TestPipelineAggregatesStageQueues(t *testing.T) {
+	intake := queue.NewPriorityQueue()
+	pipeline, err := NewPipeline([]domain.PipelineStage{{Name: "prep"}, {Name: "pack"}}, intake, newStageQueue)
+	require.NoError(t, err)
+
+	all := pipeline.All()
+	require.Len(t, all, 2)
+	assert.Equal(t, "prep", all[0].Stage)
+	assert.Same(t, intake, all[0].Queue, "Intake queue should come first")
+
+	require.NoError(t, intake.Enqueue(&domain.Order{ID: 1}))
+	require.NoError(t, pipeline.Queue("pack").Enqueue(&domain.Order{ID: 2, Stage: "pack"}))
+
+	assert.Equal(t, 2, pipeline.Size())
+	assert.Same(t, pipeline.Queue("pack"), pipeline.Locate(2))
+	assert.Same(t, intake, pipeline.Locate(99), "Unknown orders should fall back to the intake queue")
+}
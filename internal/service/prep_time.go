@@ -0,0 +1,71 @@
+package service
+
+import (
+	"sort"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+)
+
+// prepTimer derives how long a cook takes to prepare an order from its items
+// Items are spread over parallelism lanes (e.g. grill and fryer working at once);
+// with unlimited parallelism an order takes as long as its slowest item
+type prepTimer struct {
+	defaultPrepTime time.Duration // Items without their own prep time (ORDER_SERVING_DURATION)
+	parallelism     int           // Items a cook prepares at once (0 = all items of an order)
+}
+
+// orderDuration returns the time needed to prepare every item of an order
+// Orders without loaded items take defaultPrepTime (same as a single unconfigured item)
+// Time Complexity: O(f log f) where f is the number of items in the order
+func (p prepTimer) orderDuration(order *domain.Order) time.Duration {
+	if len(order.Foods) == 0 {
+		return p.defaultPrepTime
+	}
+
+	items := make([]time.Duration, len(order.Foods))
+	for i := range order.Foods {
+		items[i] = order.Foods[i].PrepTime(p.defaultPrepTime)
+	}
+
+	lanes := p.parallelism
+	if lanes <= 0 || lanes > len(items) {
+		lanes = len(items)
+	}
+
+	// Longest items first onto the least busy lane (LPT scheduling)
+	sort.Slice(items, func(i, j int) bool { return items[i] > items[j] })
+	_, makespan := scheduleLanes(items, lanes)
+	return makespan
+}
+
+// scheduleLanes assigns jobs in order to whichever lane frees up first
+// Returns when the first lane is free again (next start) and when all jobs are done (makespan)
+// Time Complexity: O(j * l) where j is jobs and l is lanes
+func scheduleLanes(jobs []time.Duration, lanes int) (nextStart, makespan time.Duration) {
+	if lanes < 1 {
+		lanes = 1
+	}
+
+	free := make([]time.Duration, lanes)
+	for _, job := range jobs {
+		earliest := 0
+		for i := range free {
+			if free[i] < free[earliest] {
+				earliest = i
+			}
+		}
+		free[earliest] += job
+	}
+
+	nextStart, makespan = free[0], free[0]
+	for _, at := range free[1:] {
+		if at < nextStart {
+			nextStart = at
+		}
+		if at > makespan {
+			makespan = at
+		}
+	}
+	return nextStart, makespan
+}
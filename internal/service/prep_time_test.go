@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/infrastructure/memory"
+	"mcmocknald-order-kiosk/internal/logger"
+	"mcmocknald-order-kiosk/pkg/queue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// prepOrder builds an order whose items take the given prep times (0 = default)
+func prepOrder(prepTimes ...time.Duration) *domain.Order {
+	order := &domain.Order{}
+	for _, prepTime := range prepTimes {
+		order.Foods = append(order.Foods, domain.Food{PrepTimeMs: prepTime.Milliseconds()})
+	}
+	return order
+}
+
+// TestOrderDurationFromItems tests how item prep times combine under different parallelism
+func TestOrderDurationFromItems(t *testing.T) {
+	threePizzasAndWater := prepOrder(15*time.Second, 15*time.Second, 15*time.Second, time.Second)
+
+	tests := []struct {
+		name        string
+		parallelism int
+		order       *domain.Order
+		want        time.Duration
+	}{
+		{"no items uses default", 0, &domain.Order{}, 10 * time.Second},
+		{"unset item uses default", 0, prepOrder(0), 10 * time.Second},
+		{"water alone", 0, prepOrder(time.Second), time.Second},
+		{"all items in parallel takes the slowest", 0, threePizzasAndWater, 15 * time.Second},
+		{"one at a time takes the sum", 1, threePizzasAndWater, 46 * time.Second},
+		{"two lanes balance the items", 2, threePizzasAndWater, 30 * time.Second},
+		{"more lanes than items", 8, threePizzasAndWater, 15 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := prepTimer{defaultPrepTime: 10 * time.Second, parallelism: tt.parallelism}
+			assert.Equal(t, tt.want, prep.orderDuration(tt.order))
+		})
+	}
+}
+
+// TestScheduleLanes tests next-start and makespan of jobs spread over lanes
+func TestScheduleLanes(t *testing.T) {
+	jobs := []time.Duration{4 * time.Second, 2 * time.Second, 2 * time.Second, time.Second}
+
+	nextStart, makespan := scheduleLanes(jobs, 2)
+	assert.Equal(t, 4*time.Second, nextStart)
+	assert.Equal(t, 5*time.Second, makespan)
+
+	nextStart, makespan = scheduleLanes(nil, 3)
+	assert.Equal(t, time.Duration(0), nextStart)
+	assert.Equal(t, time.Duration(0), makespan)
+}
+
+// TestGetOrderEstimateUsesPrepTimes tests that ETAs follow the prep times of the orders ahead
+func TestGetOrderEstimateUsesPrepTimes(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+	orderService := NewOrderService(orderRepo, userRepo, foodRepo, queue.NewPriorityQueue(), logger.NewNoOpLogger(), OrderServiceConfig{ServingDuration: 10 * time.Second})
+
+	pizza, err := foodRepo.Create(ctx, &domain.Food{Name: "Pizza", Type: domain.FoodTypeFood, PrepTimeMs: 15000})
+	require.NoError(t, err)
+	water, err := foodRepo.Create(ctx, &domain.Food{Name: "Water", Type: domain.FoodTypeDrink, PrepTimeMs: 1000})
+	require.NoError(t, err)
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	_, err = userRepo.Create(ctx, &domain.User{Name: "Cook", Role: domain.RoleCook})
+	require.NoError(t, err)
+
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{pizza.ID})
+	require.NoError(t, err)
+	_, err = orderService.CreateOrder(ctx, customer.ID, []int{water.ID})
+	require.NoError(t, err)
+	last, err := orderService.CreateOrder(ctx, customer.ID, []int{pizza.ID, water.ID})
+	require.NoError(t, err)
+
+	before := time.Now()
+	order, err := orderService.GetOrder(ctx, last.ID)
+	require.NoError(t, err)
+	require.NotNil(t, order.EstimatedStartAt)
+	require.NotNil(t, order.EstimatedCompletionAt)
+
+	// One cook: the pizza (15s) then the water (1s) come first; pizza + water in parallel takes 15s
+	assert.WithinDuration(t, before.Add(16*time.Second), *order.EstimatedStartAt, time.Second)
+	assert.Equal(t, 15*time.Second, order.EstimatedCompletionAt.Sub(*order.EstimatedStartAt))
+}
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/logger"
+	"mcmocknald-order-kiosk/pkg/queue"
+)
+
+// QueueService defines the interface for queue inspection and reordering (shift manager tools)
+// Following Interface Segregation Principle: admin operations kept apart from OrderService
+type QueueService interface {
+	// GetQueue lists the waiting orders of every priority class, in service order, with wait times
+	// With a pipeline the classes of every stage queue are listed, stage by stage
+	GetQueue(ctx context.Context) ([]QueueClassView, error)
+
+	// MoveToFront expedites a waiting order to the front of its priority class (in whichever stage queue it waits)
+	MoveToFront(ctx context.Context, orderID int) error
+
+	// SetClass moves a waiting order to the back of another priority class (in whichever stage queue it waits)
+	SetClass(ctx context.Context, orderID int, className string) error
+
+	// PersistQueue writes the waiting orders of every stage to a JSON file (shutdown in memory mode), returning how many
+	PersistQueue(ctx context.Context, path string) (int, error)
+
+	// LoadQueue re-enqueues the waiting orders of a snapshot written by PersistQueue (startup), returning how many
+	// Orders the repository does not know are re-created from the snapshot where the repository supports it
+	// (in-memory); orders it holds that are no longer PENDING are skipped; a missing file loads nothing
+	LoadQueue(ctx context.Context, path string) (int, error)
+}
+
+// QueueSnapshotFile is the on-disk form of the queue written by PersistQueue
+type QueueSnapshotFile struct {
+	SavedAt time.Time            `json:"saved_at"`
+	Size    int                  `json:"size"`
+	Classes []QueueSnapshotClass `json:"classes"` // Stage by stage, highest priority first
+}
+
+// QueueSnapshotClass lists the waiting orders of one priority class, front first
+type QueueSnapshotClass struct {
+	Stage  string          `json:"stage,omitempty"` // Pipeline stage whose queue the class belongs to
+	Name   string          `json:"name"`
+	Orders []*domain.Order `json:"orders"` // Full order records (foods, customer, stage records), enough to re-create them
+}
+
+// QueueClassView lists the waiting orders of one priority class
+type QueueClassView struct {
+	Stage  string // Pipeline stage whose queue the class belongs to (empty without a pipeline)
+	Name   string
+	Orders []QueuedOrderView
+}
+
+// QueuedOrderView is a waiting order with how long its customer has been waiting
+type QueuedOrderView struct {
+	Order      *domain.Order
+	EnqueuedAt time.Time     // When the order took its current place in line
+	WaitTime   time.Duration // Time since the order was placed
+}
+
+// orderRestorer is implemented by repositories that can re-create an order under its original ID (in-memory)
+// A database keeps its orders across restarts, so it only ever needs the orders it already holds
+type orderRestorer interface {
+	Restore(ctx context.Context, order *domain.Order) (*domain.Order, error)
+}
+
+// queueService implements queue administration
+// Following Single Responsibility Principle: only inspects and reorders the queue
+// Dependency Injection: all dependencies injected via constructor
+type queueService struct {
+	orderRepo domain.OrderRepository // Full order records for PersistQueue, source of truth on LoadQueue
+	pipeline  *Pipeline              // Stage queues (a single stage over the order queue without a pipeline)
+	logger    logger.Logger
+}
+
+// NewQueueService creates a new queue service
+// orderQueue is the intake queue; pipeline adds the queues of later stages (nil = no pipeline)
+// Following Dependency Injection pattern
+func NewQueueService(orderRepo domain.OrderRepository, orderQueue queue.OrderQueue, pipeline *Pipeline, log logger.Logger) QueueService {
+	if pipeline == nil {
+		pipeline = singleStagePipeline(orderQueue)
+	}
+
+	return &queueService{
+		orderRepo: orderRepo,
+		pipeline:  pipeline,
+		logger:    log,
+	}
+}
+
+// GetQueue lists the waiting orders of every priority class of every stage with their wait times
+// Time Complexity: O(n) where n is the number of queued orders
+func (s *queueService) GetQueue(ctx context.Context) ([]QueueClassView, error) {
+	now := time.Now()
+	var classes []QueueClassView
+	for _, stageQueue := range s.pipeline.All() {
+		snapshot, err := stageQueue.Queue.Snapshot()
+		if err != nil {
+			s.logger.Error("Failed to get queue snapshot: %v", err)
+			return nil, fmt.Errorf("failed to get queue: %w", err)
+		}
+
+		for _, class := range snapshot {
+			view := QueueClassView{Stage: stageQueue.Stage, Name: class.Name, Orders: make([]QueuedOrderView, len(class.Orders))}
+			for j, queued := range class.Orders {
+				waitingSince := queued.Order.CreatedAt
+				if waitingSince.IsZero() {
+					waitingSince = queued.EnqueuedAt
+				}
+				view.Orders[j] = QueuedOrderView{
+					Order:      queued.Order,
+					EnqueuedAt: queued.EnqueuedAt,
+					WaitTime:   now.Sub(waitingSince),
+				}
+			}
+			classes = append(classes, view)
+		}
+	}
+
+	return classes, nil
+}
+
+// MoveToFront expedites a waiting order to the front of its priority class
+// Time Complexity: O(s) stage lookups, then O(1) for in-memory, O(log n) for database
+func (s *queueService) MoveToFront(ctx context.Context, orderID int) error {
+	if err := s.pipeline.Locate(orderID).MoveToFront(orderID); err != nil {
+		return s.queueError(orderID, err)
+	}
+
+	s.logger.Info("Order %d MOVED TO FRONT of its priority class", orderID)
+	return nil
+}
+
+// SetClass moves a waiting order to the back of another priority class
+// Time Complexity: O(s) stage lookups, then O(1) for in-memory, O(log n) for database
+func (s *queueService) SetClass(ctx context.Context, orderID int, className string) error {
+	if err := s.pipeline.Locate(orderID).SetClass(orderID, className); err != nil {
+		if errors.Is(err, queue.ErrUnknownClass) {
+			return fmt.Errorf("%w: %s", ErrUnknownPriorityClass, className)
+		}
+		return s.queueError(orderID, err)
+	}
+
+	s.logger.Info("Order %d MOVED TO CLASS %s", orderID, className)
+	return nil
+}
+
+// PersistQueue writes the waiting orders of every stage, in service order per class, to a JSON file
+// Each order is written as the repository holds it (foods, customer and stage records included), so
+// LoadQueue can re-create it in a fresh in-memory repository; the queued copy is written if the lookup fails
+// The file is written next to path and renamed into place, so a crash never leaves half a snapshot
+// Time Complexity: O(n) repository lookups where n is the number of queued orders
+func (s *queueService) PersistQueue(ctx context.Context, path string) (int, error) {
+	file := QueueSnapshotFile{SavedAt: time.Now()}
+	for _, stageQueue := range s.pipeline.All() {
+		snapshot, err := stageQueue.Queue.Snapshot()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get queue: %w", err)
+		}
+
+		for _, class := range snapshot {
+			saved := QueueSnapshotClass{Stage: stageQueue.Stage, Name: class.Name, Orders: make([]*domain.Order, len(class.Orders))}
+			for j, queued := range class.Orders {
+				saved.Orders[j] = s.fullOrder(ctx, queued.Order)
+			}
+			file.Classes = append(file.Classes, saved)
+			file.Size += len(class.Orders)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return 0, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return 0, fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name()) // No-op once renamed
+
+	encoder := json.NewEncoder(tmp)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(file); err != nil {
+		tmp.Close()
+		return 0, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return 0, fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to save snapshot: %w", err)
+	}
+
+	s.logger.Info("Queue persisted to %s - %d orders", path, file.Size)
+	return file.Size, nil
+}
+
+// LoadQueue re-enqueues the orders of a snapshot, class by class and front first, into their stage queues
+// An order the repository holds is enqueued as stored (status, stage, customer, foods), and skipped once
+// no longer PENDING. An order it does not know (a fresh in-memory repository after a restart) is re-created
+// from the snapshot under its original ID first; repositories that cannot do that skip it. Orders already
+// queued (e.g. restored from the database) keep their place. An order the shift manager moved to another
+// class goes back to it.
+// Time Complexity: O(n) repository lookups where n is the number of orders in the snapshot
+func (s *queueService) LoadQueue(ctx context.Context, path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var file QueueSnapshotFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return 0, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+
+	loaded, skipped := 0, 0
+	for _, class := range file.Classes {
+		for _, saved := range class.Orders {
+			order, err := s.orderRepo.GetByID(ctx, saved.ID)
+			if err != nil {
+				order, err = s.restoreOrder(ctx, saved)
+			}
+			if err != nil || !order.IsPending() {
+				skipped++
+				continue
+			}
+
+			stageQueue := s.pipeline.Queue(order.Stage)
+			if err := stageQueue.Enqueue(order); err != nil {
+				if !errors.Is(err, queue.ErrDuplicateOrder) {
+					s.logger.Error("Failed to load order %d from snapshot: %v", order.ID, err)
+				}
+				skipped++
+				continue
+			}
+			if err := stageQueue.SetClass(order.ID, class.Name); err != nil {
+				s.logger.Error("Order %d loaded from snapshot kept its class - %v", order.ID, err)
+			}
+			loaded++
+		}
+	}
+
+	s.logger.Info("Queue loaded from %s (saved %s) - %d orders, %d skipped",
+		path, file.SavedAt.Format(time.RFC3339), loaded, skipped)
+	return loaded, nil
+}
+
+// fullOrder returns an order as the repository holds it, or the queued copy if the lookup fails
+// Time Complexity: O(1) for in-memory, O(log n) for database
+func (s *queueService) fullOrder(ctx context.Context, queued *domain.Order) *domain.Order {
+	if s.orderRepo == nil {
+		return queued
+	}
+	order, err := s.orderRepo.GetByID(ctx, queued.ID)
+	if err != nil {
+		s.logger.Error("Order %d persisted from its queued copy - %v", queued.ID, err)
+		return queued
+	}
+	return order
+}
+
+// restoreOrder re-creates a PENDING snapshot order the repository does not hold
+// Time Complexity: O(1) for in-memory
+func (s *queueService) restoreOrder(ctx context.Context, saved *domain.Order) (*domain.Order, error) {
+	restorer, ok := s.orderRepo.(orderRestorer)
+	if !ok || !saved.IsPending() {
+		return nil, ErrOrderNotFound
+	}
+
+	order, err := restorer.Restore(ctx, saved)
+	if err != nil {
+		s.logger.Error("Failed to re-create order %d from snapshot: %v", saved.ID, err)
+		return nil, err
+	}
+	return order, nil
+}
+
+// queueError maps queue errors to service errors
+// Time Complexity: O(1)
+func (s *queueService) queueError(orderID int, err error) error {
+	if errors.Is(err, queue.ErrOrderNotFound) {
+		return fmt.Errorf("%w: order %d", ErrOrderNotQueued, orderID)
+	}
+	s.logger.Error("Failed to reorder order %d: %v", orderID, err)
+	return fmt.Errorf("failed to reorder queue: %w", err)
+}
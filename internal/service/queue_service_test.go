@@ -0,0 +1,223 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+	"mcmocknald-order-kiosk/internal/infrastructure/memory"
+	"mcmocknald-order-kiosk/internal/logger"
+	"mcmocknald-order-kiosk/pkg/queue"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueueServiceGetQueue tests listing waiting orders per class with wait times
+func TestQueueServiceGetQueue(t *testing.T) {
+	ctx := context.Background()
+	orderQueue := queue.NewPriorityQueue()
+	queueService := NewQueueService(nil, orderQueue, nil, logger.NewNoOpLogger())
+
+	placed := time.Now().Add(-time.Minute)
+	require.NoError(t, orderQueue.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer, CreatedAt: placed}))
+	require.NoError(t, orderQueue.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer, CreatedAt: placed}))
+
+	classes, err := queueService.GetQueue(ctx)
+	require.NoError(t, err)
+	require.Len(t, classes, 2)
+
+	assert.Equal(t, "VIP", classes[0].Name)
+	require.Len(t, classes[0].Orders, 1)
+	assert.Equal(t, 2, classes[0].Orders[0].Order.ID)
+	assert.GreaterOrEqual(t, classes[0].Orders[0].WaitTime, time.Minute, "Wait time counts from when the order was placed")
+
+	assert.Equal(t, "Regular", classes[1].Name)
+	require.Len(t, classes[1].Orders, 1)
+}
+
+// TestQueueServiceReorderErrors tests mapping of queue errors to service errors
+func TestQueueServiceReorderErrors(t *testing.T) {
+	ctx := context.Background()
+	orderQueue := queue.NewPriorityQueue()
+	queueService := NewQueueService(nil, orderQueue, nil, logger.NewNoOpLogger())
+
+	require.NoError(t, orderQueue.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+
+	assert.ErrorIs(t, queueService.MoveToFront(ctx, 99), ErrOrderNotQueued)
+	assert.ErrorIs(t, queueService.SetClass(ctx, 99, "VIP"), ErrOrderNotQueued)
+	assert.ErrorIs(t, queueService.SetClass(ctx, 1, "Platinum"), ErrUnknownPriorityClass)
+
+	require.NoError(t, queueService.SetClass(ctx, 1, "VIP"))
+	require.NoError(t, queueService.MoveToFront(ctx, 1))
+
+	next, err := orderQueue.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 1, next.ID)
+}
+
+// TestQueueServicePersistQueue tests writing the waiting orders to a JSON snapshot
+func TestQueueServicePersistQueue(t *testing.T) {
+	ctx := context.Background()
+	orderQueue := queue.NewPriorityQueue()
+	queueService := NewQueueService(nil, orderQueue, nil, logger.NewNoOpLogger())
+
+	require.NoError(t, orderQueue.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, orderQueue.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, orderQueue.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}))
+
+	path := filepath.Join(t.TempDir(), "data", "queue_snapshot.json")
+	saved, err := queueService.PersistQueue(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var snapshot QueueSnapshotFile
+	require.NoError(t, json.Unmarshal(data, &snapshot))
+	assert.Equal(t, 3, snapshot.Size)
+	require.Len(t, snapshot.Classes, 2)
+	assert.Equal(t, "VIP", snapshot.Classes[0].Name)
+	require.Len(t, snapshot.Classes[1].Orders, 2)
+	assert.Equal(t, 1, snapshot.Classes[1].Orders[0].ID, "Orders should be saved front first")
+	assert.Equal(t, 3, orderQueue.Size(), "Persisting should leave the queue untouched")
+}
+
+// TestQueueServiceCoversEveryStage tests that listing, reordering and persisting reach later pipeline stages
+func TestQueueServiceCoversEveryStage(t *testing.T) {
+	ctx := context.Background()
+	intake := queue.NewPriorityQueue()
+	pipeline, err := NewPipeline([]domain.PipelineStage{{Name: "prep"}, {Name: "pack"}}, intake, newStageQueue)
+	require.NoError(t, err)
+	queueService := NewQueueService(nil, intake, pipeline, logger.NewNoOpLogger())
+
+	require.NoError(t, intake.Enqueue(&domain.Order{ID: 1, Stage: "prep", CustomerRole: domain.RoleRegularCustomer}))
+	packQueue := pipeline.Queue("pack")
+	require.NoError(t, packQueue.Enqueue(&domain.Order{ID: 2, Stage: "pack", CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, packQueue.Enqueue(&domain.Order{ID: 3, Stage: "pack", CustomerRole: domain.RoleRegularCustomer}))
+
+	classes, err := queueService.GetQueue(ctx)
+	require.NoError(t, err)
+	require.Len(t, classes, 4, "Every class of every stage should be listed")
+	assert.Equal(t, "prep", classes[0].Stage)
+	assert.Equal(t, "pack", classes[3].Stage)
+	require.Len(t, classes[3].Orders, 2)
+
+	require.NoError(t, queueService.MoveToFront(ctx, 3), "Orders waiting for a later stage should be expedited")
+	next, err := packQueue.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 3, next.ID)
+
+	require.NoError(t, queueService.SetClass(ctx, 2, "VIP"), "Orders waiting for a later stage should be reclassified")
+	next, err = packQueue.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.ID)
+
+	saved, err := queueService.PersistQueue(ctx, filepath.Join(t.TempDir(), "queue_snapshot.json"))
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved, "Orders of every stage should be persisted")
+}
+
+// TestQueueServiceLoadQueue tests re-enqueuing a snapshot, skipping orders the repository does not hold PENDING
+func TestQueueServiceLoadQueue(t *testing.T) {
+	ctx := context.Background()
+	userRepo := memory.NewUserRepository()
+	foodRepo := memory.NewFoodRepository()
+	orderRepo := memory.NewOrderRepository(userRepo, foodRepo)
+
+	customer, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		_, err := orderRepo.Create(ctx, &domain.Order{OrderedBy: customer.ID}, nil)
+		require.NoError(t, err)
+	}
+	require.NoError(t, orderRepo.UpdateStatus(ctx, 3, domain.OrderStatusComplete))
+
+	// Snapshot of a queue where order 2 was moved to VIP; order 99 is unknown
+	path := filepath.Join(t.TempDir(), "queue_snapshot.json")
+	savedQueue := queue.NewPriorityQueue()
+	for _, id := range []int{1, 2, 3, 99} {
+		require.NoError(t, savedQueue.Enqueue(&domain.Order{ID: id, CustomerRole: domain.RoleRegularCustomer}))
+	}
+	require.NoError(t, savedQueue.SetClass(2, "VIP"))
+	_, err = NewQueueService(orderRepo, savedQueue, nil, logger.NewNoOpLogger()).PersistQueue(ctx, path)
+	require.NoError(t, err)
+
+	orderQueue := queue.NewPriorityQueue()
+	queueService := NewQueueService(orderRepo, orderQueue, nil, logger.NewNoOpLogger())
+
+	loaded, err := queueService.LoadQueue(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded, "Completed and unknown orders should be skipped")
+
+	next, err := orderQueue.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 2, next.ID, "Order should go back to the class it was moved to")
+	assert.Equal(t, "John Doe", next.CustomerName, "Loaded orders should come from the repository")
+
+	loaded, err = queueService.LoadQueue(ctx, filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+	assert.Zero(t, loaded, "A missing snapshot should load nothing")
+}
+
+// TestQueueServiceLoadQueueAfterRestart tests that a snapshot re-creates its orders in a fresh in-memory repository
+func TestQueueServiceLoadQueueAfterRestart(t *testing.T) {
+	ctx := context.Background()
+	orderService, userRepo, _, orderRepo, orderQueue := setupOrderServiceTest(t)
+
+	regular, err := userRepo.Create(ctx, &domain.User{Name: "John Doe", Role: domain.RoleRegularCustomer})
+	require.NoError(t, err)
+	vip, err := userRepo.Create(ctx, &domain.User{Name: "Jane Doe", Role: domain.RoleVIPCustomer})
+	require.NoError(t, err)
+	for _, customer := range []*domain.User{regular, regular, vip, regular} {
+		_, err := orderService.CreateOrder(ctx, customer.ID, []int{1, 3})
+		require.NoError(t, err)
+	}
+	queueService := NewQueueService(orderRepo, orderQueue, nil, logger.NewNoOpLogger())
+	require.NoError(t, queueService.MoveToFront(ctx, 4))
+	_, err = orderService.CancelOrder(ctx, 2)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "queue_snapshot.json")
+	saved, err := queueService.PersistQueue(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved)
+
+	// Restart: empty repositories and queue
+	freshUsers := memory.NewUserRepository()
+	freshOrders := memory.NewOrderRepository(freshUsers, memory.NewFoodRepository())
+	freshQueue := queue.NewPriorityQueue()
+	loaded, err := NewQueueService(freshOrders, freshQueue, nil, logger.NewNoOpLogger()).LoadQueue(ctx, path)
+	require.NoError(t, err)
+	assert.Equal(t, 3, loaded, "Every saved order should be re-created")
+
+	assert.Equal(t, []int{3, 4, 1}, drainQueueIDs(t, freshQueue), "Orders should come back in the saved order")
+
+	restored, err := freshOrders.GetByID(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, domain.OrderStatusPending, restored.Status)
+	assert.Equal(t, "Jane Doe", restored.CustomerName)
+	assert.Equal(t, domain.RoleVIPCustomer, restored.CustomerRole)
+	require.Len(t, restored.Foods, 2, "Foods should survive without the food repository")
+	assert.Equal(t, "Soda", restored.Foods[1].Name)
+
+	next, err := freshOrders.Create(ctx, &domain.Order{OrderedBy: regular.ID}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5, next.ID, "New orders should not reuse restored IDs")
+}
+
+// drainQueueIDs dequeues every order and returns their IDs in service order
+func drainQueueIDs(t *testing.T, orderQueue queue.OrderQueue) []int {
+	var ids []int
+	for orderQueue.Size() > 0 {
+		order, err := orderQueue.Dequeue()
+		require.NoError(t, err)
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
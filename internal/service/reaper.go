@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/logger"
+)
+
+// StuckOrderReaper defines the interface for the background recovery of orphaned SERVING orders
+// Following Interface Segregation Principle: lifecycle only, recovery itself lives in OrderService
+type StuckOrderReaper interface {
+	// Start recovers stuck orders every interval until ctx is done or Stop is called (no-op when disabled)
+	Start(ctx context.Context)
+
+	// Stop ends the sweeps and waits for a running one to finish
+	Stop()
+}
+
+// stuckOrderReaper periodically calls OrderService.RecoverStuckOrders
+// Following Single Responsibility Principle: only schedules recovery sweeps
+// Dependency Injection: all dependencies injected via constructor
+type stuckOrderReaper struct {
+	orderService OrderService
+	logger       logger.Logger
+	interval     time.Duration // Time between sweeps (0 = reaper disabled)
+	multiple     int           // Orders SERVING longer than multiple x expected cook time are stuck
+
+	stopChan chan struct{}  // Signal to stop sweeping
+	wg       sync.WaitGroup // Wait for the sweep loop to finish
+}
+
+// NewStuckOrderReaper creates a new stuck-order reaper
+// interval 0 disables it; multiple is how many expected cook times an order may stay SERVING
+// Following Dependency Injection pattern
+func NewStuckOrderReaper(orderService OrderService, log logger.Logger, interval time.Duration, multiple int) StuckOrderReaper {
+	return &stuckOrderReaper{
+		orderService: orderService,
+		logger:       log,
+		interval:     interval,
+		multiple:     multiple,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start sweeps for stuck orders every interval in the background
+// Time Complexity: O(1) to start; each sweep is O(n) where n is the number of SERVING orders
+func (r *stuckOrderReaper) Start(ctx context.Context) {
+	if r.interval <= 0 {
+		return
+	}
+
+	r.logger.Info("Stuck-order reaper started: checking every %v for orders SERVING over %dx their cook time",
+		r.interval, r.multiple)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if _, err := r.orderService.RecoverStuckOrders(ctx, r.multiple); err != nil {
+					r.logger.Error("Stuck-order sweep failed: %v", err)
+				}
+			case <-r.stopChan:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the sweeps and waits for a running one to finish
+func (r *stuckOrderReaper) Stop() {
+	close(r.stopChan)
+	r.wg.Wait()
+	r.logger.Info("Stuck-order reaper stopped")
+}
@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_order_queue;
+
+-- Drop queue columns
+ALTER TABLE "order" DROP COLUMN IF EXISTS queued_at;
+ALTER TABLE "order" DROP COLUMN IF EXISTS queue_priority;
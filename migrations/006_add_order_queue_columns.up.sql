@@ -0,0 +1,21 @@
+-- Queue columns for the Postgres-backed order queue (QUEUE_BACKEND=postgres)
+-- An order is queued while queued_at IS NOT NULL; claiming it clears both columns
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS queue_priority SMALLINT;
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS queued_at TIMESTAMP WITH TIME ZONE;
+
+-- Queue existing PENDING orders by customer role (VIP first) and creation time
+-- Assumes the default priority classes (VIP = 0, everyone else = 1); the API re-ranks these rows
+-- with the configured QUEUE_PRIORITY_CLASSES on startup (postgres.OrderQueue.Rerank)
+UPDATE "order" o
+SET queue_priority = CASE WHEN u.role = 'VIP Customer' THEN 0 ELSE 1 END,
+    queued_at = o.created_at
+FROM "user" u
+WHERE o.ordered_by = u.id
+  AND o.status = 'PENDING'
+  AND o.assigned_cook_user IS NULL
+  AND o.deleted_at IS NULL
+  AND o.queued_at IS NULL;
+
+-- Claim order index: highest priority first, then FIFO (SELECT ... FOR UPDATE SKIP LOCKED)
+CREATE INDEX IF NOT EXISTS idx_order_queue ON "order"(queue_priority, queued_at, id)
+    WHERE queued_at IS NOT NULL AND deleted_at IS NULL;
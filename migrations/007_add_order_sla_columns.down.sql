@@ -0,0 +1,6 @@
+-- Drop indexes
+DROP INDEX IF EXISTS idx_order_sla_breached;
+
+-- Drop SLA columns
+ALTER TABLE "order" DROP COLUMN IF EXISTS sla_breached;
+ALTER TABLE "order" DROP COLUMN IF EXISTS deadline;
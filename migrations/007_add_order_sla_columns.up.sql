@@ -0,0 +1,7 @@
+-- SLA tracking: deadline is set when the order is created (per customer role)
+-- sla_breached is recorded when the order completes after its deadline
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS deadline TIMESTAMP WITH TIME ZONE;
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS sla_breached BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Index for SLA breach statistics
+CREATE INDEX IF NOT EXISTS idx_order_sla_breached ON "order"(sla_breached) WHERE sla_breached AND deleted_at IS NULL;
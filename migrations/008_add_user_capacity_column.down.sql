@@ -0,0 +1,2 @@
+-- Drop cook capacity column
+ALTER TABLE "user" DROP COLUMN IF EXISTS capacity;
@@ -0,0 +1,3 @@
+-- Cook capacity: how many orders a cook serves concurrently
+-- 0 means the cook follows the service default (COOK_CAPACITY)
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0);
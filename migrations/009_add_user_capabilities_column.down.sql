@@ -0,0 +1,2 @@
+-- Drop cook capabilities column
+ALTER TABLE "user" DROP COLUMN IF EXISTS capabilities;
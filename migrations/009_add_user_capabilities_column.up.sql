@@ -0,0 +1,3 @@
+-- Cook stations: the food types a cook can prepare (e.g. {Drink} for a drink station)
+-- An empty array means the cook can prepare every food type
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS capabilities TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,2 @@
+-- Drop food preparation time column
+ALTER TABLE food DROP COLUMN IF EXISTS prep_time_ms;
@@ -0,0 +1,11 @@
+-- Per-item preparation time in milliseconds (0 = use ORDER_SERVING_DURATION)
+ALTER TABLE food ADD COLUMN IF NOT EXISTS prep_time_ms INTEGER NOT NULL DEFAULT 0 CHECK (prep_time_ms >= 0);
+
+-- Give the seeded menu realistic relative preparation times (only where still unset)
+UPDATE food SET prep_time_ms = 8000  WHERE name = 'Burger'    AND prep_time_ms = 0;
+UPDATE food SET prep_time_ms = 5000  WHERE name = 'Fries'     AND prep_time_ms = 0;
+UPDATE food SET prep_time_ms = 15000 WHERE name = 'Pizza'     AND prep_time_ms = 0;
+UPDATE food SET prep_time_ms = 2000  WHERE name = 'Soda'      AND prep_time_ms = 0;
+UPDATE food SET prep_time_ms = 1000  WHERE name = 'Water'     AND prep_time_ms = 0;
+UPDATE food SET prep_time_ms = 3000  WHERE name = 'Ice Cream' AND prep_time_ms = 0;
+UPDATE food SET prep_time_ms = 4000  WHERE name = 'Cake'      AND prep_time_ms = 0;
@@ -0,0 +1,2 @@
+-- Drop cook completion mode column
+ALTER TABLE "user" DROP COLUMN IF EXISTS completion_mode;
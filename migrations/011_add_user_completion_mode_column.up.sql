@@ -0,0 +1,3 @@
+-- How a cook's orders are completed: timer (cook bots, also when empty) or manual (staff on a tablet)
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS completion_mode TEXT NOT NULL DEFAULT ''
+    CHECK (completion_mode IN ('', 'timer', 'manual'));
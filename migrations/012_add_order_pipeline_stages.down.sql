@@ -0,0 +1,5 @@
+-- Drop order pipeline stages
+DROP INDEX IF EXISTS idx_order_stage_order_id;
+DROP TABLE IF EXISTS order_stage;
+ALTER TABLE "user" DROP COLUMN IF EXISTS stage;
+ALTER TABLE "order" DROP COLUMN IF EXISTS stage;
@@ -0,0 +1,15 @@
+-- Order pipeline: the stage an order is in and the stage a cook works (empty = no pipeline / first stage)
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS stage TEXT NOT NULL DEFAULT '';
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS stage TEXT NOT NULL DEFAULT '';
+
+-- One row per cook's turn at an order's stage (completed_at stays NULL when the turn was cut short)
+CREATE TABLE IF NOT EXISTS order_stage (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES "order"(id),
+    stage TEXT NOT NULL,
+    cook_id INTEGER NOT NULL REFERENCES "user"(id),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_stage_order_id ON order_stage(order_id);
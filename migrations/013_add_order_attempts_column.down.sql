@@ -0,0 +1,2 @@
+-- Drop attempts column
+ALTER TABLE "order" DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,3 @@
+-- Failed cooking attempts since the order was last requeued by hand
+-- An order that fails ORDER_MAX_ATTEMPTS times moves to status FAILED (covered by idx_order_status)
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS attempts INTEGER NOT NULL DEFAULT 0 CHECK (attempts >= 0);
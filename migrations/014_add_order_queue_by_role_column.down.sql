@@ -0,0 +1,2 @@
+-- Drop queue_by_role column
+ALTER TABLE "order" DROP COLUMN IF EXISTS queue_by_role;
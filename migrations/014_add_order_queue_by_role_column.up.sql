@@ -0,0 +1,11 @@
+-- Marks queued orders whose queue_priority follows their customer's role (Enqueue, migration 006 backfill)
+-- Orders placed deliberately (EnqueueAtFront, MoveToFront, SetClass) are FALSE and keep their class when
+-- postgres.OrderQueue.Rerank applies the configured QUEUE_PRIORITY_CLASSES on startup
+ALTER TABLE "order" ADD COLUMN IF NOT EXISTS queue_by_role BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Until now an order still in its original place was recognised by queued_at = created_at
+UPDATE "order"
+SET queue_by_role = TRUE
+WHERE queued_at IS NOT NULL
+  AND queued_at = created_at
+  AND deleted_at IS NULL;
@@ -0,0 +1,96 @@
+package queue
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AdmissionLimits caps how many orders may wait in the queue (0 = unlimited)
+// Limits apply to new orders only (Admit, AdmitAndEnqueue); re-queued orders (EnqueueAtFront, startup restore)
+// were already admitted and are never refused
+type AdmissionLimits struct {
+	MaxDepth       int   // Maximum queued orders across all classes
+	MaxClassDepths []int // Maximum queued orders per priority class, highest first (empty = no class limits)
+}
+
+// QueueFullError reports which depth limit refused an order
+// Matches ErrQueueFull with errors.Is
+type QueueFullError struct {
+	Class string // Priority class whose limit was hit (empty for the total limit)
+	Depth int    // Orders currently queued against the limit
+	Limit int    // Configured limit
+}
+
+// Error implements error
+func (e *QueueFullError) Error() string {
+	if e.Class == "" {
+		return fmt.Sprintf("%v: %d orders waiting (limit %d)", ErrQueueFull, e.Depth, e.Limit)
+	}
+	return fmt.Sprintf("%v: %d %s orders waiting (limit %d)", ErrQueueFull, e.Depth, e.Class, e.Limit)
+}
+
+// Unwrap lets errors.Is(err, ErrQueueFull) match
+func (e *QueueFullError) Unwrap() error {
+	return ErrQueueFull
+}
+
+// Excess returns how many queued orders must be taken before a new order fits
+// Time Complexity: O(1)
+func (e *QueueFullError) Excess() int {
+	return e.Depth - e.Limit + 1
+}
+
+// Check returns a QueueFullError if one more order would exceed the limits
+// total is the whole queue depth; classDepth is the depth of the order's class (index classIdx)
+// Time Complexity: O(1)
+func (l AdmissionLimits) Check(total int, class string, classIdx, classDepth int) error {
+	if l.MaxDepth > 0 && total >= l.MaxDepth {
+		return &QueueFullError{Depth: total, Limit: l.MaxDepth}
+	}
+	if classIdx < len(l.MaxClassDepths) {
+		if limit := l.MaxClassDepths[classIdx]; limit > 0 && classDepth >= limit {
+			return &QueueFullError{Class: class, Depth: classDepth, Limit: limit}
+		}
+	}
+	return nil
+}
+
+// ValidateClassCount checks per-class limits match the number of priority classes
+// Time Complexity: O(1)
+func (l AdmissionLimits) ValidateClassCount(classes int) error {
+	if len(l.MaxClassDepths) > 0 && len(l.MaxClassDepths) != classes {
+		return fmt.Errorf("got %d class depth limits for %d priority classes", len(l.MaxClassDepths), classes)
+	}
+	return nil
+}
+
+// WithAdmissionLimits caps the total and per-class queue depth checked by Admit and AdmitAndEnqueue
+// Panics if per-class limits are given but do not match the number of priority classes
+func WithAdmissionLimits(limits AdmissionLimits) Option {
+	return func(pq *PriorityQueue) {
+		pq.limits = limits
+	}
+}
+
+// ParseClassDepths parses comma-separated per-class depth limits, highest class first
+// Format: "50,200" - 0 leaves a class unlimited; an empty spec means no class limits
+// Time Complexity: O(n) where n is the length of the specification
+func ParseClassDepths(spec string) ([]int, error) {
+	var depths []int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		depth, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid depth %q: %w", part, err)
+		}
+		if depth < 0 {
+			return nil, fmt.Errorf("class depths must be non-negative (got %d)", depth)
+		}
+		depths = append(depths, depth)
+	}
+	return depths, nil
+}
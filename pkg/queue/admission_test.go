@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAdmitTotalLimit tests that Admit refuses new orders once the total depth is reached
+func TestAdmitTotalLimit(t *testing.T) {
+	pq := NewPriorityQueue(WithAdmissionLimits(AdmissionLimits{MaxDepth: 2}))
+
+	for id := 1; id <= 2; id++ {
+		order := &domain.Order{ID: id, CustomerRole: domain.RoleRegularCustomer}
+		require.NoError(t, pq.Admit(order))
+		require.NoError(t, pq.Enqueue(order))
+	}
+
+	err := pq.Admit(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer})
+	require.ErrorIs(t, err, ErrQueueFull)
+
+	var full *QueueFullError
+	require.ErrorAs(t, err, &full)
+	assert.Equal(t, "", full.Class, "Total limit should not name a class")
+	assert.Equal(t, 2, full.Depth)
+	assert.Equal(t, 2, full.Limit)
+	assert.Equal(t, 1, full.Excess(), "One order must be taken before a new one fits")
+
+	// Taking an order frees a slot
+	_, err = pq.Dequeue()
+	require.NoError(t, err)
+	assert.NoError(t, pq.Admit(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}))
+}
+
+// TestAdmitClassLimit tests that a per-class limit only refuses orders of that class
+func TestAdmitClassLimit(t *testing.T) {
+	pq := NewPriorityQueue(WithAdmissionLimits(AdmissionLimits{MaxClassDepths: []int{0, 1}}))
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+
+	err := pq.Admit(&domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer})
+	var full *QueueFullError
+	require.ErrorAs(t, err, &full)
+	assert.Equal(t, "Regular", full.Class)
+
+	assert.NoError(t, pq.Admit(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}), "VIP class is unlimited")
+}
+
+// TestAdmitDoesNotLimitRequeue tests that orders returned to the front are never refused
+func TestAdmitDoesNotLimitRequeue(t *testing.T) {
+	pq := NewPriorityQueue(WithAdmissionLimits(AdmissionLimits{MaxDepth: 1}))
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.EnqueueAtFront(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer}))
+
+	assert.Equal(t, 2, pq.Size())
+}
+
+// TestAdmissionLimitsClassCountMismatchPanics tests that per-class limits must cover every class
+func TestAdmissionLimitsClassCountMismatchPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewPriorityQueue(WithAdmissionLimits(AdmissionLimits{MaxClassDepths: []int{10}}))
+	})
+}
+
+// TestParseClassDepths tests parsing of the per-class depth specification
+func TestParseClassDepths(t *testing.T) {
+	depths, err := ParseClassDepths(" 50, 0 ")
+	require.NoError(t, err)
+	assert.Equal(t, []int{50, 0}, depths)
+
+	depths, err = ParseClassDepths("")
+	require.NoError(t, err)
+	assert.Empty(t, depths)
+
+	for _, spec := range []string{"10,x", "-1,5"} {
+		_, err := ParseClassDepths(spec)
+		assert.Error(t, err, "spec %q should be rejected", spec)
+	}
+}
+
+// TestAdmitAndEnqueueRefusesAtLimit tests that AdmitAndEnqueue enqueues within the limits and refuses beyond them
+func TestAdmitAndEnqueueRefusesAtLimit(t *testing.T) {
+	pq := NewPriorityQueue(WithAdmissionLimits(AdmissionLimits{MaxClassDepths: []int{0, 1}}))
+
+	require.NoError(t, pq.AdmitAndEnqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+
+	err := pq.AdmitAndEnqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer})
+	require.ErrorIs(t, err, ErrQueueFull)
+	_, err = pq.Position(2)
+	assert.Error(t, err, "Refused order should not be enqueued")
+
+	require.NoError(t, pq.AdmitAndEnqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}), "VIP class is unlimited")
+	assert.ErrorIs(t, pq.AdmitAndEnqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}), ErrDuplicateOrder)
+	assert.Equal(t, 2, pq.Size())
+}
+
+// TestAdmitAndEnqueueConcurrentNeverOvershoots tests that concurrent admissions never exceed the total limit
+func TestAdmitAndEnqueueConcurrentNeverOvershoots(t *testing.T) {
+	const limit = 5
+	pq := NewPriorityQueue(WithAdmissionLimits(AdmissionLimits{MaxDepth: limit}))
+
+	var wg sync.WaitGroup
+	var admitted atomic.Int32
+	for id := 1; id <= 50; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			if pq.AdmitAndEnqueue(&domain.Order{ID: id, CustomerRole: domain.RoleRegularCustomer}) == nil {
+				admitted.Add(1)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(limit), admitted.Load())
+	assert.Equal(t, limit, pq.Size())
+}
@@ -0,0 +1,49 @@
+package queue
+
+import "mcmocknald-order-kiosk/internal/domain"
+
+// Capabilities is the set of food types a cook can prepare (e.g. a drink or dessert station)
+// A nil or empty set means the cook can prepare every type (generalist)
+type Capabilities []domain.FoodType
+
+// CanPrepare reports whether every item of the order is of a type in the set
+// Orders are routed whole: a mixed order goes to a cook that can make all of its parts
+// (OrderService refuses orders no cook of a staffed stage can make, so none waits forever)
+// Time Complexity: O(f * t) where f is foods in the order and t is types in the set (both tiny)
+func (c Capabilities) CanPrepare(order *domain.Order) bool {
+	if len(c) == 0 {
+		return true
+	}
+
+	for _, food := range order.Foods {
+		if !c.Has(food.Type) {
+			return false
+		}
+	}
+	return true
+}
+
+// Has reports whether the set includes a food type (an empty set includes every type)
+// Time Complexity: O(t) where t is the number of types in the set
+func (c Capabilities) Has(foodType domain.FoodType) bool {
+	if len(c) == 0 {
+		return true
+	}
+
+	for _, capable := range c {
+		if capable == foodType {
+			return true
+		}
+	}
+	return false
+}
+
+// Strings returns the food types as plain strings (e.g. for SQL array parameters)
+// Time Complexity: O(t)
+func (c Capabilities) Strings() []string {
+	types := make([]string, len(c))
+	for i, foodType := range c {
+		types[i] = string(foodType)
+	}
+	return types
+}
@@ -0,0 +1,140 @@
+package queue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stationOrder builds a queued order containing one item of each given food type
+func stationOrder(id int, role domain.RoleType, types ...domain.FoodType) *domain.Order {
+	order := &domain.Order{ID: id, CustomerRole: role}
+	for _, foodType := range types {
+		order.Foods = append(order.Foods, domain.Food{Type: foodType})
+	}
+	return order
+}
+
+// TestCapabilitiesCanPrepare tests that an order matches only when every item is covered
+func TestCapabilitiesCanPrepare(t *testing.T) {
+	drinks := stationOrder(1, domain.RoleRegularCustomer, domain.FoodTypeDrink)
+	mixed := stationOrder(2, domain.RoleRegularCustomer, domain.FoodTypeFood, domain.FoodTypeDrink)
+
+	tests := []struct {
+		name  string
+		caps  Capabilities
+		order *domain.Order
+		want  bool
+	}{
+		{"generalist takes anything", nil, mixed, true},
+		{"drink station takes drinks", Capabilities{domain.FoodTypeDrink}, drinks, true},
+		{"drink station skips mixed order", Capabilities{domain.FoodTypeDrink}, mixed, false},
+		{"multi-station takes mixed order", Capabilities{domain.FoodTypeFood, domain.FoodTypeDrink}, mixed, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.caps.CanPrepare(tt.order))
+		})
+	}
+}
+
+// TestDequeueForSkipsOrdersTheCookCannotPrepare tests that unmatched orders keep their place in line
+func TestDequeueForSkipsOrdersTheCookCannotPrepare(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	require.NoError(t, pq.Enqueue(stationOrder(1, domain.RoleVIPCustomer, domain.FoodTypeFood)))
+	require.NoError(t, pq.Enqueue(stationOrder(2, domain.RoleRegularCustomer, domain.FoodTypeFood)))
+	require.NoError(t, pq.Enqueue(stationOrder(3, domain.RoleRegularCustomer, domain.FoodTypeDrink)))
+	require.NoError(t, pq.Enqueue(stationOrder(4, domain.RoleRegularCustomer, domain.FoodTypeDrink)))
+
+	order, err := pq.DequeueFor(Capabilities{domain.FoodTypeDrink})
+	require.NoError(t, err)
+	assert.Equal(t, 3, order.ID, "Drink station should take the first drink order")
+
+	assert.Equal(t, 3, pq.Size())
+	assert.Equal(t, map[string][]int{"VIP": {1}, "Regular": {2, 4}}, snapshotIDs(t, pq),
+		"Skipped orders should keep their place")
+
+	position, err := pq.Position(4)
+	require.NoError(t, err)
+	assert.Equal(t, 3, position)
+
+	// A generalist still follows plain priority order
+	order, err = pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.ID)
+}
+
+// TestDequeueForNoMatchingOrder tests the errors for an empty queue and a queue with nothing to prepare
+func TestDequeueForNoMatchingOrder(t *testing.T) {
+	pq := NewPriorityQueue()
+	desserts := Capabilities{domain.FoodTypeDessert}
+
+	_, err := pq.DequeueFor(desserts)
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+
+	require.NoError(t, pq.Enqueue(stationOrder(1, domain.RoleRegularCustomer, domain.FoodTypeFood)))
+
+	_, err = pq.DequeueFor(desserts)
+	assert.ErrorIs(t, err, ErrNoMatchingOrder)
+	assert.Equal(t, 1, pq.Size(), "Unmatched order should stay queued")
+}
+
+// TestDequeueWaitForWakesOnlyCapableWaiter tests that an enqueue skips parked waiters that cannot prepare the order
+func TestDequeueWaitForWakesOnlyCapableWaiter(t *testing.T) {
+	pq := NewPriorityQueue()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	drinkResult := make(chan *domain.Order, 1)
+	go func() {
+		order, err := pq.DequeueWaitFor(ctx, Capabilities{domain.FoodTypeDrink})
+		if err == nil {
+			drinkResult <- order
+		}
+	}()
+
+	// Park the drink station first so it would be woken first without capability matching
+	require.Eventually(t, func() bool {
+		pq.mu.RLock()
+		defer pq.mu.RUnlock()
+		return len(pq.waiters) == 1
+	}, time.Second, time.Millisecond)
+
+	foodResult := make(chan *domain.Order, 1)
+	go func() {
+		order, err := pq.DequeueWaitFor(ctx, Capabilities{domain.FoodTypeFood})
+		if err == nil {
+			foodResult <- order
+		}
+	}()
+	require.Eventually(t, func() bool {
+		pq.mu.RLock()
+		defer pq.mu.RUnlock()
+		return len(pq.waiters) == 2
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, pq.Enqueue(stationOrder(1, domain.RoleRegularCustomer, domain.FoodTypeFood)))
+
+	select {
+	case order := <-foodResult:
+		assert.Equal(t, 1, order.ID)
+	case <-time.After(time.Second):
+		t.Fatal("Food station was not woken for a food order")
+	}
+
+	require.NoError(t, pq.Enqueue(stationOrder(2, domain.RoleRegularCustomer, domain.FoodTypeDrink)))
+
+	select {
+	case order := <-drinkResult:
+		assert.Equal(t, 2, order.ID)
+	case <-time.After(time.Second):
+		t.Fatal("Drink station was not woken for a drink order")
+	}
+}
@@ -0,0 +1,81 @@
+package queue
+
+import "container/heap"
+
+// deadlineHeap is a min-heap of queue items in earliest-deadline-first order (see dueBefore)
+// Used per class by deadline-ordered policies (EDF), where FIFO position says nothing about urgency
+// Removed items are skipped lazily, like the tombstones of the class deques
+// Implements container/heap.Interface; not safe for concurrent use - PriorityQueue guards it with its own mutex
+type deadlineHeap []queueItem
+
+// Len returns the number of items in the heap (including removed items not yet dropped)
+// Time Complexity: O(1)
+func (h deadlineHeap) Len() int {
+	return len(h)
+}
+
+// Less reports whether item i is served before item j
+// Time Complexity: O(1)
+func (h deadlineHeap) Less(i, j int) bool {
+	return dueBefore(h[i], h[j])
+}
+
+// Swap exchanges two items
+// Time Complexity: O(1)
+func (h deadlineHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+}
+
+// Push appends an item (use heap.Push to keep the heap ordered)
+// Time Complexity: O(1) amortized
+func (h *deadlineHeap) Push(x any) {
+	*h = append(*h, x.(queueItem))
+}
+
+// Pop removes the last item (use heap.Pop to take the earliest item)
+// Time Complexity: O(1)
+func (h *deadlineHeap) Pop() any {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = queueItem{} // Clear reference for GC
+	*h = old[:n-1]
+	return item
+}
+
+// Retain keeps only the items keep reports true for and restores the heap order (used to drop removed items)
+// Time Complexity: O(n)
+func (h *deadlineHeap) Retain(keep func(queueItem) bool) {
+	old := *h
+	kept := old[:0]
+	for _, item := range old {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	clear(old[len(kept):]) // Clear references for GC
+	*h = kept
+	heap.Init(h)
+}
+
+// dueBefore reports whether item a is served before item b within a deadline-ordered class
+// Orders put at the front (EnqueueAtFront, MoveToFront) come first, most recent first, as in a FIFO class;
+// then the earliest deadline (orders without one last); equal deadlines stay FIFO
+// Time Complexity: O(1)
+func dueBefore(a, b queueItem) bool {
+	if a.atFront != b.atFront {
+		return a.atFront
+	}
+	if a.atFront {
+		return a.seq > b.seq
+	}
+
+	aDue, bDue := a.order.HasDeadline(), b.order.HasDeadline()
+	if aDue != bDue {
+		return aDue
+	}
+	if aDue && !a.order.Deadline.Equal(*b.order.Deadline) {
+		return a.order.Deadline.Before(*b.order.Deadline)
+	}
+	return a.seq < b.seq
+}
@@ -0,0 +1,120 @@
+package queue
+
+// minDequeCapacity is the smallest ring buffer allocated (must be a power of two)
+const minDequeCapacity = 16
+
+// deque is a growable ring-buffer double-ended queue of queue items
+// Push and pop at both ends are O(1) amortized; the buffer doubles when full
+// and halves when a quarter full, so no operation ever shifts the whole queue
+// Not safe for concurrent use - PriorityQueue guards it with its own mutex
+type deque struct {
+	buf   []queueItem // Ring buffer; len(buf) is always zero or a power of two
+	head  int         // Index of the first item
+	count int         // Number of items stored
+}
+
+// Len returns the number of items in the deque
+// Time Complexity: O(1)
+func (d *deque) Len() int {
+	return d.count
+}
+
+// PushBack appends an item to the back
+// Time Complexity: O(1) amortized
+func (d *deque) PushBack(item queueItem) {
+	d.growIfFull()
+	d.buf[d.index(d.count)] = item
+	d.count++
+}
+
+// PushFront prepends an item to the front
+// Time Complexity: O(1) amortized
+func (d *deque) PushFront(item queueItem) {
+	d.growIfFull()
+	d.head = d.index(len(d.buf) - 1)
+	d.buf[d.head] = item
+	d.count++
+}
+
+// PopFront removes and returns the first item; the deque must not be empty
+// Time Complexity: O(1) amortized
+func (d *deque) PopFront() queueItem {
+	item := d.buf[d.head]
+	d.buf[d.head] = queueItem{} // Clear reference for GC
+	d.head = d.index(1)
+	d.count--
+	d.shrinkIfSparse()
+	return item
+}
+
+// Front returns the first item without removing it; the deque must not be empty
+// Time Complexity: O(1)
+func (d *deque) Front() queueItem {
+	return d.buf[d.head]
+}
+
+// At returns the i-th item from the front (0-based); i must be in [0, Len())
+// Time Complexity: O(1)
+func (d *deque) At(i int) queueItem {
+	return d.buf[d.index(i)]
+}
+
+// Retain keeps only the items keep reports true for, in order (used to drop removed items)
+// Time Complexity: O(n)
+func (d *deque) Retain(keep func(queueItem) bool) {
+	kept := 0
+	for i := 0; i < d.count; i++ {
+		if item := d.At(i); keep(item) {
+			d.buf[d.index(kept)] = item
+			kept++
+		}
+	}
+	for i := kept; i < d.count; i++ {
+		d.buf[d.index(i)] = queueItem{} // Clear reference for GC
+	}
+	d.count = kept
+	d.shrinkIfSparse()
+}
+
+// index maps a logical offset from head to a physical buffer index
+// Time Complexity: O(1) - bitmask since the capacity is a power of two
+func (d *deque) index(offset int) int {
+	return (d.head + offset) & (len(d.buf) - 1)
+}
+
+// growIfFull doubles the buffer when it has no free slot
+// Time Complexity: O(n) when resizing, O(1) amortized
+func (d *deque) growIfFull() {
+	if d.count < len(d.buf) {
+		return
+	}
+	newCap := len(d.buf) * 2
+	if newCap == 0 {
+		newCap = minDequeCapacity
+	}
+	d.resize(newCap)
+}
+
+// shrinkIfSparse halves the buffer when it is at most a quarter full (reclaims memory after peaks)
+// Time Complexity: O(n) when resizing, O(1) amortized
+func (d *deque) shrinkIfSparse() {
+	if len(d.buf) > minDequeCapacity && d.count <= len(d.buf)/4 {
+		d.resize(len(d.buf) / 2)
+	}
+}
+
+// resize copies the items in order into a new buffer of the given capacity
+// Time Complexity: O(n)
+func (d *deque) resize(capacity int) {
+	buf := make([]queueItem, capacity)
+	if d.count > 0 {
+		if d.head+d.count <= len(d.buf) {
+			copy(buf, d.buf[d.head:d.head+d.count])
+		} else {
+			n := copy(buf, d.buf[d.head:])
+			copy(buf[n:], d.buf[:d.count-n])
+		}
+	}
+	d.buf = buf
+	d.head = 0
+}
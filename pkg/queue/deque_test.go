@@ -0,0 +1,86 @@
+package queue
+
+import (
+	"testing"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dequeItem builds a queue item whose order ID identifies it
+func dequeItem(id int) queueItem {
+	return queueItem{order: &domain.Order{ID: id}}
+}
+
+// dequeIDs returns the order IDs in the deque from front to back
+func dequeIDs(d *deque) []int {
+	ids := make([]int, d.Len())
+	for i := range ids {
+		ids[i] = d.At(i).order.ID
+	}
+	return ids
+}
+
+// TestDequePushPopOrder tests FIFO and front-insertion ordering
+func TestDequePushPopOrder(t *testing.T) {
+	var d deque
+
+	d.PushBack(dequeItem(2))
+	d.PushBack(dequeItem(3))
+	d.PushFront(dequeItem(1))
+
+	assert.Equal(t, []int{1, 2, 3}, dequeIDs(&d))
+	assert.Equal(t, 1, d.Front().order.ID)
+
+	assert.Equal(t, 1, d.PopFront().order.ID)
+	assert.Equal(t, 2, d.PopFront().order.ID)
+	assert.Equal(t, 3, d.PopFront().order.ID)
+	assert.Equal(t, 0, d.Len())
+}
+
+// TestDequeGrowsAcrossWrapAround tests that resizing preserves order when the ring has wrapped
+func TestDequeGrowsAcrossWrapAround(t *testing.T) {
+	var d deque
+
+	// Advance head so subsequent pushes wrap around the end of the buffer
+	for i := 0; i < minDequeCapacity; i++ {
+		d.PushBack(dequeItem(-1))
+	}
+	for i := 0; i < minDequeCapacity-2; i++ {
+		d.PopFront()
+	}
+	d.PopFront()
+	d.PopFront()
+
+	expected := []int{}
+	for i := 0; i < 5*minDequeCapacity; i++ {
+		d.PushBack(dequeItem(i))
+		expected = append(expected, i)
+	}
+	for i := 1; i <= 3; i++ {
+		d.PushFront(dequeItem(-i))
+		expected = append([]int{-i}, expected...)
+	}
+
+	require.Equal(t, len(expected), d.Len())
+	assert.Equal(t, expected, dequeIDs(&d))
+}
+
+// TestDequeShrinksAfterDrain tests that the buffer is released back towards the minimum once drained
+func TestDequeShrinksAfterDrain(t *testing.T) {
+	var d deque
+
+	for i := 0; i < 10000; i++ {
+		d.PushBack(dequeItem(i))
+	}
+	peak := len(d.buf)
+
+	for i := 0; i < 10000; i++ {
+		require.Equal(t, i, d.PopFront().order.ID)
+	}
+
+	assert.Less(t, len(d.buf), peak)
+	assert.Equal(t, minDequeCapacity, len(d.buf))
+}
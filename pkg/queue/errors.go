@@ -6,6 +6,21 @@ var (
 	// ErrEmptyQueue is returned when attempting to dequeue from an empty queue
 	ErrEmptyQueue = errors.New("queue is empty")
 
+	// ErrNoMatchingOrder is returned when orders are queued but none fits the cook's capabilities
+	ErrNoMatchingOrder = errors.New("no queued order matches the cook's capabilities")
+
 	// ErrNilOrder is returned when attempting to enqueue a nil order
 	ErrNilOrder = errors.New("cannot enqueue nil order")
+
+	// ErrDuplicateOrder is returned when enqueuing an order that is already queued
+	ErrDuplicateOrder = errors.New("order is already queued")
+
+	// ErrOrderNotFound is returned when removing an order that is not in the queue
+	ErrOrderNotFound = errors.New("order is not in the queue")
+
+	// ErrUnknownClass is returned when moving an order to a priority class that does not exist
+	ErrUnknownClass = errors.New("unknown priority class")
+
+	// ErrQueueFull is matched by QueueFullError when admission limits refuse a new order
+	ErrQueueFull = errors.New("queue is full")
 )
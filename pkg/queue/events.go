@@ -0,0 +1,123 @@
+package queue
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+)
+
+// EventType identifies what changed in the queue
+type EventType string
+
+// Queue event types
+const (
+	EventEnqueued        EventType = "enqueued"          // New order added to the back of its class
+	EventDequeued        EventType = "dequeued"          // Order taken by a cook
+	EventRequeuedAtFront EventType = "requeued_at_front" // Order returned to the front of its class
+	EventRemoved         EventType = "removed"           // Order taken out of the queue (e.g. cancelled)
+	EventMovedToFront    EventType = "moved_to_front"    // Queued order expedited to the front of its class
+	EventClassChanged    EventType = "class_changed"     // Queued order moved to the back of another class
+)
+
+// DefaultSubscriptionBuffer is the event buffer used when Subscribe is given a non-positive size
+const DefaultSubscriptionBuffer = 64
+
+// Event describes a single queue change
+type Event struct {
+	Type  EventType     // What happened
+	Order *domain.Order // Order that was added or taken out
+	Class string        // Priority class of the order
+	Size  int           // Total queue size after the change
+	At    time.Time     // When the change happened
+}
+
+// Subscription receives queue events until it is closed
+// Events are buffered; when the buffer is full new events are dropped (and counted) rather than
+// blocking the queue, so a slow subscriber can never stall Enqueue or Dequeue
+type Subscription struct {
+	events  chan Event
+	dropped atomic.Uint64 // Events lost because the buffer was full
+	bus     *EventBus
+	once    sync.Once
+}
+
+// Events returns the channel of queue events; it is closed by Close
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Dropped returns how many events were discarded because the subscriber fell behind
+// Time Complexity: O(1)
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Close stops delivery and closes the events channel; safe to call more than once
+// Time Complexity: O(s) where s is the number of subscribers
+func (s *Subscription) Close() {
+	s.once.Do(func() {
+		s.bus.unsubscribe(s)
+	})
+}
+
+// EventBus fans queue events out to subscribers without ever blocking the publisher (zero value is ready to use)
+// Following Observer Pattern: queue implementations hold one and publish on every change
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers []*Subscription
+}
+
+// Subscribe registers a new subscriber with the given buffer size (DefaultSubscriptionBuffer if <= 0)
+// Time Complexity: O(1)
+func (b *EventBus) Subscribe(buffer int) *Subscription {
+	if buffer <= 0 {
+		buffer = DefaultSubscriptionBuffer
+	}
+
+	sub := &Subscription{events: make(chan Event, buffer), bus: b}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers = append(b.subscribers, sub)
+	return sub
+}
+
+// unsubscribe removes a subscriber and closes its channel
+// Time Complexity: O(s) where s is the number of subscribers
+func (b *EventBus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i, s := range b.subscribers {
+		if s == sub {
+			b.subscribers = append(b.subscribers[:i], b.subscribers[i+1:]...)
+			break
+		}
+	}
+	close(sub.events)
+}
+
+// HasSubscribers reports whether anyone is listening (lets publishers skip building costly events)
+// Time Complexity: O(1)
+func (b *EventBus) HasSubscribers() bool {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers) > 0
+}
+
+// Publish delivers an event to every subscriber with room in its buffer; never blocks
+// Time Complexity: O(s) where s is the number of subscribers
+func (b *EventBus) Publish(event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
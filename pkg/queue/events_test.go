@@ -0,0 +1,102 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// receive reads the next event or fails the test after a short timeout
+func receive(t *testing.T, sub *Subscription) Event {
+	select {
+	case event := <-sub.Events():
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for queue event")
+		return Event{}
+	}
+}
+
+// TestSubscribeReceivesEveryChange tests event types, orders, classes and sizes for each queue operation
+func TestSubscribeReceivesEveryChange(t *testing.T) {
+	pq := NewPriorityQueue()
+	sub := pq.Subscribe(10)
+	defer sub.Close()
+
+	vip := &domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer}
+	regular := &domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer}
+
+	require.NoError(t, pq.Enqueue(vip))
+	require.NoError(t, pq.Enqueue(regular))
+	_, err := pq.Dequeue()
+	require.NoError(t, err)
+	require.NoError(t, pq.EnqueueAtFront(vip))
+	_, err = pq.Remove(regular.ID)
+	require.NoError(t, err)
+
+	expected := []struct {
+		eventType EventType
+		orderID   int
+		class     string
+		size      int
+	}{
+		{EventEnqueued, 1, "VIP", 1},
+		{EventEnqueued, 2, "Regular", 2},
+		{EventDequeued, 1, "VIP", 1},
+		{EventRequeuedAtFront, 1, "VIP", 2},
+		{EventRemoved, 2, "Regular", 1},
+	}
+	for _, want := range expected {
+		event := receive(t, sub)
+		assert.Equal(t, want.eventType, event.Type)
+		assert.Equal(t, want.orderID, event.Order.ID)
+		assert.Equal(t, want.class, event.Class)
+		assert.Equal(t, want.size, event.Size)
+		assert.False(t, event.At.IsZero())
+	}
+}
+
+// TestSlowSubscriberDoesNotBlock tests that a subscriber that never reads drops events instead of blocking
+func TestSlowSubscriberDoesNotBlock(t *testing.T) {
+	pq := NewPriorityQueue()
+	slow := pq.Subscribe(1)
+	defer slow.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for id := 1; id <= 100; id++ {
+			_ = pq.Enqueue(&domain.Order{ID: id, CustomerRole: domain.RoleRegularCustomer})
+		}
+		for !pq.IsEmpty() {
+			_, _ = pq.Dequeue()
+		}
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Enqueue/Dequeue blocked on a slow subscriber")
+	}
+
+	assert.Equal(t, uint64(199), slow.Dropped(), "Only the first event fits in the buffer")
+	assert.Equal(t, EventEnqueued, receive(t, slow).Type)
+}
+
+// TestSubscriptionClose tests that closing stops delivery and closes the channel
+func TestSubscriptionClose(t *testing.T) {
+	pq := NewPriorityQueue()
+	sub := pq.Subscribe(0)
+
+	sub.Close()
+	sub.Close() // Safe to call twice
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer}))
+
+	_, open := <-sub.Events()
+	assert.False(t, open, "Events channel should be closed")
+}
@@ -0,0 +1,182 @@
+package queue
+
+import (
+	"sync"
+	"testing"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// conformanceSettings configures every registered policy for the default VIP/Regular classes
+var conformanceSettings = PolicySettings{Weights: []int{3, 1}}
+
+// newConformanceQueue creates a queue using the named registered policy
+func newConformanceQueue(t *testing.T, name string) *PriorityQueue {
+	policy, err := NewSchedulingPolicy(name, conformanceSettings)
+	require.NoError(t, err)
+	return NewPriorityQueue(WithSchedulingPolicy(policy))
+}
+
+// conformanceOrder returns an order whose class alternates in an uneven pattern
+func conformanceOrder(id int) *domain.Order {
+	role := domain.RoleRegularCustomer
+	if id%3 != 0 {
+		role = domain.RoleVIPCustomer
+	}
+	return &domain.Order{ID: id, CustomerRole: role}
+}
+
+// TestSchedulingPolicyConformance runs the queue contract against every registered policy
+// Policies may order classes differently but must never lose, duplicate or reorder orders within a class
+func TestSchedulingPolicyConformance(t *testing.T) {
+	for _, name := range SchedulingPolicies() {
+		t.Run(name, func(t *testing.T) {
+			t.Run("EmptyQueue", func(t *testing.T) {
+				pq := newConformanceQueue(t, name)
+
+				_, err := pq.Dequeue()
+				assert.ErrorIs(t, err, ErrEmptyQueue)
+				_, err = pq.Peek()
+				assert.ErrorIs(t, err, ErrEmptyQueue)
+			})
+
+			t.Run("ServesEveryOrderOnceInFIFOPerClass", func(t *testing.T) {
+				pq := newConformanceQueue(t, name)
+				for id := 1; id <= 60; id++ {
+					require.NoError(t, pq.Enqueue(conformanceOrder(id)))
+				}
+
+				seen := make(map[int]bool)
+				lastID := make(map[domain.RoleType]int)
+				for !pq.IsEmpty() {
+					peeked, err := pq.Peek()
+					require.NoError(t, err)
+					order, err := pq.Dequeue()
+					require.NoError(t, err)
+
+					assert.Equal(t, peeked.ID, order.ID, "Peek must match the next Dequeue")
+					assert.False(t, seen[order.ID], "Order %d served twice", order.ID)
+					assert.Greater(t, order.ID, lastID[order.CustomerRole], "FIFO violated within %s", order.CustomerRole)
+					seen[order.ID] = true
+					lastID[order.CustomerRole] = order.ID
+				}
+				assert.Len(t, seen, 60)
+			})
+
+			t.Run("ServesLoneClass", func(t *testing.T) {
+				pq := newConformanceQueue(t, name)
+				for id := 1; id <= 5; id++ {
+					require.NoError(t, pq.Enqueue(&domain.Order{ID: id, CustomerRole: domain.RoleRegularCustomer}))
+				}
+
+				for id := 1; id <= 5; id++ {
+					order, err := pq.Dequeue()
+					require.NoError(t, err, "A waiting class must be served even if it is not the top class")
+					assert.Equal(t, id, order.ID)
+				}
+			})
+
+			t.Run("EnqueueAtFrontLeadsItsClass", func(t *testing.T) {
+				pq := newConformanceQueue(t, name)
+				for id := 1; id <= 6; id++ {
+					require.NoError(t, pq.Enqueue(conformanceOrder(id)))
+				}
+				requeued := &domain.Order{ID: 100, CustomerRole: domain.RoleRegularCustomer}
+				require.NoError(t, pq.EnqueueAtFront(requeued))
+
+				for {
+					order, err := pq.Dequeue()
+					require.NoError(t, err)
+					if order.CustomerRole == domain.RoleRegularCustomer {
+						assert.Equal(t, requeued.ID, order.ID, "Requeued order must be first in its class")
+						break
+					}
+				}
+			})
+
+			t.Run("RemovedOrdersAreSkipped", func(t *testing.T) {
+				pq := newConformanceQueue(t, name)
+				for id := 1; id <= 12; id++ {
+					require.NoError(t, pq.Enqueue(conformanceOrder(id)))
+				}
+				for _, id := range []int{1, 3, 6, 7} {
+					_, err := pq.Remove(id)
+					require.NoError(t, err)
+				}
+
+				served := 0
+				for !pq.IsEmpty() {
+					order, err := pq.Dequeue()
+					require.NoError(t, err)
+					assert.NotContains(t, []int{1, 3, 6, 7}, order.ID)
+					served++
+				}
+				assert.Equal(t, 8, served)
+			})
+
+			t.Run("ConcurrentAccess", func(t *testing.T) {
+				pq := newConformanceQueue(t, name)
+				const producers, perProducer = 4, 50
+
+				var wg sync.WaitGroup
+				for p := 0; p < producers; p++ {
+					wg.Add(1)
+					go func(p int) {
+						defer wg.Done()
+						for i := 1; i <= perProducer; i++ {
+							assert.NoError(t, pq.Enqueue(conformanceOrder(p*perProducer+i)))
+						}
+					}(p)
+				}
+
+				var mu sync.Mutex
+				seen := make(map[int]bool)
+				for c := 0; c < producers; c++ {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						for i := 0; i < perProducer; i++ {
+							if order, err := pq.Dequeue(); err == nil {
+								mu.Lock()
+								seen[order.ID] = true
+								mu.Unlock()
+							}
+						}
+					}()
+				}
+				wg.Wait()
+
+				for !pq.IsEmpty() {
+					order, err := pq.Dequeue()
+					require.NoError(t, err)
+					seen[order.ID] = true
+				}
+				assert.Len(t, seen, producers*perProducer, "No order may be lost")
+			})
+		})
+	}
+}
+
+// TestNewSchedulingPolicy tests selecting policies by name
+func TestNewSchedulingPolicy(t *testing.T) {
+	policy, err := NewSchedulingPolicy(PolicyStrict, PolicySettings{})
+	require.NoError(t, err)
+	assert.IsType(t, &StrictPolicy{}, policy)
+
+	policy, err = NewSchedulingPolicy(PolicyWeighted, PolicySettings{Weights: []int{2, 1}})
+	require.NoError(t, err)
+	assert.IsType(t, &WeightedPolicy{}, policy)
+
+	policy, err = NewSchedulingPolicy(PolicyEDF, PolicySettings{})
+	require.NoError(t, err)
+	assert.IsType(t, &EDFPolicy{}, policy)
+
+	_, err = NewSchedulingPolicy("lottery", PolicySettings{})
+	assert.Error(t, err, "Unknown policy should be rejected")
+
+	_, err = NewSchedulingPolicy(PolicyWeighted, PolicySettings{})
+	assert.Error(t, err, "Weighted policy requires weights")
+}
@@ -0,0 +1,48 @@
+package queue
+
+import "time"
+
+// EDFPolicy serves the class whose head order is closest to breaching its SLA deadline
+// (earliest-deadline-first). Within a class FIFO position says nothing about urgency (a class may map
+// roles with different SLA targets, and orders can be put at the front or moved between classes), so
+// the queue serves each class by deadline too: its head is its earliest-deadline order, except orders
+// explicitly put at the front (EnqueueAtFront, MoveToFront), which still go first.
+// Heads without a deadline are served after every head that has one (strict priority among them);
+// equal deadlines go to the higher class
+// Stateless, so it is safe to share between queues
+type EDFPolicy struct{}
+
+// NewEDFPolicy creates an earliest-deadline-first policy
+func NewEDFPolicy() *EDFPolicy {
+	return &EDFPolicy{}
+}
+
+// Next returns the class whose head has the earliest deadline
+// Time Complexity: O(c) where c is the number of non-empty classes
+func (p *EDFPolicy) Next(heads []ClassHead, now time.Time) int {
+	earliest := 0
+	for i, head := range heads[1:] {
+		if deadlineBefore(head, heads[earliest]) {
+			earliest = i + 1
+		}
+	}
+	return heads[earliest].Class
+}
+
+// deadlineBefore reports whether head a is due strictly before head b (no deadline = due last)
+// Time Complexity: O(1)
+func deadlineBefore(a, b ClassHead) bool {
+	if !a.Order.HasDeadline() {
+		return false
+	}
+	if !b.Order.HasDeadline() {
+		return true
+	}
+	return a.Order.Deadline.Before(*b.Order.Deadline)
+}
+
+// Served is a no-op: earliest-deadline-first keeps no state between dequeues
+func (p *EDFPolicy) Served(class int) {}
+
+// ordersByDeadline makes the queue serve each class by deadline (see deadlineOrderer)
+func (p *EDFPolicy) ordersByDeadline() {}
@@ -0,0 +1,190 @@
+package queue
+
+import (
+	"testing"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// orderDue creates an order for a role with a deadline relative to base
+func orderDue(id int, role domain.RoleType, base time.Time, in time.Duration) *domain.Order {
+	deadline := base.Add(in)
+	return &domain.Order{ID: id, CustomerRole: role, Deadline: &deadline}
+}
+
+// TestEDFServesEarliestDeadlineFirst tests that a Regular order closer to breaching beats a VIP order
+func TestEDFServesEarliestDeadlineFirst(t *testing.T) {
+	pq := NewPriorityQueue(WithSchedulingPolicy(NewEDFPolicy()))
+	now := time.Now()
+
+	require.NoError(t, pq.Enqueue(orderDue(1, domain.RoleRegularCustomer, now, time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(2, domain.RoleVIPCustomer, now, 3*time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(3, domain.RoleRegularCustomer, now, 8*time.Minute)))
+
+	var ids []int
+	for !pq.IsEmpty() {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		ids = append(ids, order.ID)
+	}
+
+	assert.Equal(t, []int{1, 2, 3}, ids)
+}
+
+// TestEDFPeekMatchesDequeue tests that Peek reports the order Dequeue will return
+func TestEDFPeekMatchesDequeue(t *testing.T) {
+	pq := NewPriorityQueue(WithSchedulingPolicy(NewEDFPolicy()))
+	now := time.Now()
+
+	require.NoError(t, pq.Enqueue(orderDue(1, domain.RoleVIPCustomer, now, 3*time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(2, domain.RoleRegularCustomer, now, 2*time.Minute)))
+
+	peeked, err := pq.Peek()
+	require.NoError(t, err)
+	dequeued, err := pq.Dequeue()
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, peeked.ID)
+	assert.Equal(t, peeked.ID, dequeued.ID)
+}
+
+// TestEDFTieAndMissingDeadline tests tie-breaking by class and that orders without a deadline go last
+func TestEDFTieAndMissingDeadline(t *testing.T) {
+	policy := NewEDFPolicy()
+	now := time.Now()
+
+	vip := ClassHead{Class: 0, Order: orderDue(1, domain.RoleVIPCustomer, now, time.Minute)}
+	regular := ClassHead{Class: 1, Order: orderDue(2, domain.RoleRegularCustomer, now, time.Minute)}
+	assert.Equal(t, 0, policy.Next([]ClassHead{vip, regular}, now), "Equal deadlines should favour the higher class")
+
+	noDeadline := ClassHead{Class: 0, Order: &domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}}
+	assert.Equal(t, 1, policy.Next([]ClassHead{noDeadline, regular}, now), "Orders with a deadline should go first")
+
+	bare := ClassHead{Class: 1, Order: &domain.Order{ID: 4, CustomerRole: domain.RoleRegularCustomer}}
+	assert.Equal(t, 0, policy.Next([]ClassHead{noDeadline, bare}, now), "Without deadlines EDF falls back to strict priority")
+}
+
+// drainIDs dequeues every order and returns their IDs in service order
+func drainIDs(t *testing.T, pq *PriorityQueue) []int {
+	var ids []int
+	for !pq.IsEmpty() {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		ids = append(ids, order.ID)
+	}
+	return ids
+}
+
+// TestEDFServesMultiRoleClassByDeadline tests that a class mapping roles with different SLA targets is served by deadline
+func TestEDFServesMultiRoleClassByDeadline(t *testing.T) {
+	pq := NewPriorityQueue(
+		WithPriorityClasses([]PriorityClass{
+			{Name: "Everyone", Roles: []domain.RoleType{domain.RoleVIPCustomer, domain.RoleRegularCustomer}, Default: true},
+		}),
+		WithSchedulingPolicy(NewEDFPolicy()),
+	)
+	now := time.Now()
+
+	require.NoError(t, pq.Enqueue(orderDue(1, domain.RoleRegularCustomer, now, 10*time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(2, domain.RoleVIPCustomer, now, 2*time.Minute)))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(orderDue(4, domain.RoleVIPCustomer, now, 5*time.Minute)))
+
+	peeked, err := pq.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 2, peeked.ID, "Peek should report the earliest deadline, not the FIFO head")
+
+	position, err := pq.Position(1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, position, "Position should follow deadline order")
+
+	snapshot, err := pq.Snapshot()
+	require.NoError(t, err)
+	var listed []int
+	for _, queued := range snapshot[0].Orders {
+		listed = append(listed, queued.Order.ID)
+	}
+	assert.Equal(t, []int{2, 4, 1, 3}, listed, "Snapshot should list the class in service order")
+
+	assert.Equal(t, []int{2, 4, 1, 3}, drainIDs(t, pq), "Orders without a deadline should go last")
+}
+
+// TestEDFReorderingKeepsDeadlineOrder tests that front insertions and class changes do not hide earlier deadlines
+func TestEDFReorderingKeepsDeadlineOrder(t *testing.T) {
+	pq := NewPriorityQueue(WithSchedulingPolicy(NewEDFPolicy()))
+	now := time.Now()
+
+	require.NoError(t, pq.Enqueue(orderDue(1, domain.RoleRegularCustomer, now, 9*time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(2, domain.RoleRegularCustomer, now, 8*time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(3, domain.RoleVIPCustomer, now, 7*time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(4, domain.RoleVIPCustomer, now, time.Minute)))
+
+	// Moved to the back of Regular, but still the most urgent order there
+	require.NoError(t, pq.SetClass(4, "Regular"))
+
+	// A returned order goes to the front of its class; the rest of the class stays by deadline
+	require.NoError(t, pq.EnqueueAtFront(orderDue(5, domain.RoleVIPCustomer, now, 20*time.Minute)))
+
+	// Heads: VIP 5 (front, due in 20m) vs Regular 4 (due in 1m)
+	assert.Equal(t, []int{4, 2, 1, 5, 3}, drainIDs(t, pq))
+
+	// An expedited order goes first in its class whatever its deadline
+	require.NoError(t, pq.Enqueue(orderDue(6, domain.RoleRegularCustomer, now, time.Minute)))
+	require.NoError(t, pq.Enqueue(orderDue(7, domain.RoleRegularCustomer, now, 30*time.Minute)))
+	require.NoError(t, pq.MoveToFront(7))
+	assert.Equal(t, []int{7, 6}, drainIDs(t, pq))
+}
+
+// TestEDFDequeueForPicksEarliestMatchingDeadline tests that capability-aware dequeues also follow deadlines
+func TestEDFDequeueForPicksEarliestMatchingDeadline(t *testing.T) {
+	pq := NewPriorityQueue(WithSchedulingPolicy(NewEDFPolicy()))
+	now := time.Now()
+
+	withItem := func(order *domain.Order, foodType domain.FoodType) *domain.Order {
+		order.Foods = []domain.Food{{Type: foodType}}
+		return order
+	}
+	require.NoError(t, pq.Enqueue(withItem(orderDue(1, domain.RoleRegularCustomer, now, 9*time.Minute), domain.FoodTypeDrink)))
+	require.NoError(t, pq.Enqueue(withItem(orderDue(2, domain.RoleRegularCustomer, now, time.Minute), domain.FoodTypeFood)))
+	require.NoError(t, pq.Enqueue(withItem(orderDue(3, domain.RoleRegularCustomer, now, 3*time.Minute), domain.FoodTypeDrink)))
+
+	order, err := pq.DequeueFor(Capabilities{domain.FoodTypeDrink})
+	require.NoError(t, err)
+	assert.Equal(t, 3, order.ID, "Drink station should take the most urgent drink order")
+
+	assert.Equal(t, []int{2, 1}, drainIDs(t, pq))
+}
+
+// TestEDFCompactsTakenAndRemovedOrders tests that a long-deadline order at the front does not let
+// orders taken through the deadline heap or removed behind it pile up
+func TestEDFCompactsTakenAndRemovedOrders(t *testing.T) {
+	pq := NewPriorityQueue(WithSchedulingPolicy(NewEDFPolicy()))
+	now := time.Now()
+	station := Capabilities{domain.FoodTypeFood}
+
+	require.NoError(t, pq.Enqueue(orderDue(1, domain.RoleRegularCustomer, now, time.Hour)))
+	for id := 2; id < 1000; id++ {
+		require.NoError(t, pq.Enqueue(orderDue(id, domain.RoleRegularCustomer, now, time.Minute)))
+
+		var err error
+		switch id % 3 {
+		case 0:
+			_, err = pq.Dequeue()
+		case 1:
+			_, err = pq.DequeueFor(station)
+		default:
+			_, err = pq.Remove(id)
+		}
+		require.NoError(t, err)
+	}
+
+	class := pq.classes[pq.nameIndex["Regular"]]
+	assert.Equal(t, 1, class.live)
+	assert.LessOrEqual(t, class.items.Len(), 2, "Deque should hold at most twice the live orders")
+	assert.LessOrEqual(t, class.due.Len(), 2, "Deadline heap should hold at most twice the live orders")
+	assert.Equal(t, []int{1}, drainIDs(t, pq))
+}
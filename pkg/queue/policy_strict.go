@@ -0,0 +1,52 @@
+package queue
+
+import "time"
+
+// StrictPolicy serves the highest non-empty priority class first (the original VIP-then-FIFO rule)
+// Optional aging: the oldest lower-class head that has waited maxWait is served ahead of higher classes
+// Stateless, so it is safe to share between queues
+type StrictPolicy struct {
+	maxWait time.Duration // Anti-starvation bound for lower classes (0 disables aging)
+}
+
+// NewStrictPolicy creates a strict priority policy; a zero or negative maxWait disables aging
+func NewStrictPolicy(maxWait time.Duration) *StrictPolicy {
+	if maxWait < 0 {
+		maxWait = 0
+	}
+	return &StrictPolicy{maxWait: maxWait}
+}
+
+// WithMaxRegularWait enables anti-starvation aging for orders below the top priority class
+// Once the oldest lower-class order has waited maxWait it is dequeued ahead of higher classes
+// A zero or negative duration disables aging (strict priority)
+// Aging configures the strict policy: NewPriorityQueue panics if another policy is set with WithSchedulingPolicy
+func WithMaxRegularWait(maxWait time.Duration) Option {
+	return func(pq *PriorityQueue) {
+		pq.maxRegularWait = maxWait
+	}
+}
+
+// Next returns the highest class, unless a lower-class head has aged past maxWait
+// Time Complexity: O(c) where c is the number of non-empty classes
+func (p *StrictPolicy) Next(heads []ClassHead, now time.Time) int {
+	if p.maxWait <= 0 {
+		return heads[0].Class
+	}
+
+	aged := -1
+	for i, head := range heads[1:] {
+		if now.Sub(head.EnqueuedAt) >= p.maxWait &&
+			(aged == -1 || head.EnqueuedAt.Before(heads[aged].EnqueuedAt)) {
+			aged = i + 1
+		}
+	}
+
+	if aged != -1 {
+		return heads[aged].Class
+	}
+	return heads[0].Class
+}
+
+// Served is a no-op: strict priority keeps no state between dequeues
+func (p *StrictPolicy) Served(class int) {}
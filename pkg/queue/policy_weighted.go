@@ -0,0 +1,71 @@
+package queue
+
+import (
+	"fmt"
+	"time"
+)
+
+// WeightedPolicy implements weighted round-robin across priority classes
+// The current class keeps its turn until it has served its weight (or runs dry); then the
+// next non-empty class in priority order takes over, wrapping around to the top.
+// With weights 3,1 cooks take three VIP orders, then one Regular order, while both are waiting.
+// Stateful: create one per queue (the queue's lock guards it)
+type WeightedPolicy struct {
+	weights []int // Orders per turn for each class, highest first
+	current int   // Class whose turn it is
+	served  int   // Orders served from current in this turn
+}
+
+// NewWeightedPolicy creates a weighted round-robin policy with one positive weight per class
+func NewWeightedPolicy(weights []int) *WeightedPolicy {
+	return &WeightedPolicy{weights: append([]int(nil), weights...)}
+}
+
+// WithWeightedRoundRobin replaces strict priority with weighted round-robin scheduling
+// weights[i] is how many consecutive orders class i gets per round while other classes are waiting;
+// an empty class is skipped, so a lone class is always served
+// Panics if the number of weights does not match the classes or a weight is not positive
+func WithWeightedRoundRobin(weights []int) Option {
+	return func(pq *PriorityQueue) {
+		if err := validateClassWeights(weights); err != nil {
+			panic(fmt.Sprintf("queue: invalid class weights: %v", err))
+		}
+		pq.policy = NewWeightedPolicy(weights)
+	}
+}
+
+// validateClassCount ensures there is exactly one weight per priority class
+func (p *WeightedPolicy) validateClassCount(classes int) error {
+	if len(p.weights) != classes {
+		return fmt.Errorf("%d class weights for %d priority classes", len(p.weights), classes)
+	}
+	return nil
+}
+
+// Next keeps the current class while it has turns left, otherwise moves to the next waiting class
+// Time Complexity: O(c) where c is the number of non-empty classes
+func (p *WeightedPolicy) Next(heads []ClassHead, now time.Time) int {
+	for _, head := range heads {
+		if head.Class == p.current && p.served < p.weights[p.current] {
+			return p.current
+		}
+	}
+
+	for _, head := range heads {
+		if head.Class > p.current {
+			return head.Class
+		}
+	}
+	return heads[0].Class // Wrap around to the highest waiting class
+}
+
+// Served advances the round-robin turn
+// Time Complexity: O(1)
+func (p *WeightedPolicy) Served(class int) {
+	if class == p.current && p.served < p.weights[class] {
+		p.served++
+		return
+	}
+	p.current = class
+	p.served = 1
+}
@@ -0,0 +1,107 @@
+package queue
+
+import (
+	"fmt"
+	"strings"
+
+	"mcmocknald-order-kiosk/internal/domain"
+)
+
+// PriorityClass describes one tier of the order queue
+// Classes are ordered from highest to lowest priority; FIFO is maintained within each class
+type PriorityClass struct {
+	Name    string            // Display name of the class (e.g. "VIP")
+	Roles   []domain.RoleType // Customer roles mapped to this class
+	Default bool              // Receives orders whose role matches no class
+}
+
+// Classifier maps an order to a priority class name based on any order attribute
+// Returning an empty string falls back to the role-based mapping
+type Classifier func(order *domain.Order) string
+
+// DefaultPriorityClasses returns the built-in two-tier setup: VIP first, then Regular
+// Time Complexity: O(1)
+func DefaultPriorityClasses() []PriorityClass {
+	return []PriorityClass{
+		{Name: "VIP", Roles: []domain.RoleType{domain.RoleVIPCustomer}},
+		{Name: "Regular", Roles: []domain.RoleType{domain.RoleRegularCustomer}, Default: true},
+	}
+}
+
+// ParsePriorityClasses parses an ordered class specification (highest priority first)
+// Format: "Name=Role A,Role B;Name2=Role C,*" - classes separated by ';', roles by ','
+// A '*' role marks the default class; without one, the last class is the default
+// An empty spec returns DefaultPriorityClasses
+// Time Complexity: O(n) where n is the length of the specification
+func ParsePriorityClasses(spec string) ([]PriorityClass, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return DefaultPriorityClasses(), nil
+	}
+
+	var classes []PriorityClass
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, roles, _ := strings.Cut(part, "=")
+		class := PriorityClass{Name: strings.TrimSpace(name)}
+		for _, role := range strings.Split(roles, ",") {
+			role = strings.TrimSpace(role)
+			switch role {
+			case "":
+			case "*":
+				class.Default = true
+			default:
+				class.Roles = append(class.Roles, domain.RoleType(role))
+			}
+		}
+		classes = append(classes, class)
+	}
+
+	if err := validatePriorityClasses(classes); err != nil {
+		return nil, err
+	}
+
+	return classes, nil
+}
+
+// validatePriorityClasses checks names are unique, roles map to one class and at most one default exists
+// Time Complexity: O(n) where n is the total number of roles
+func validatePriorityClasses(classes []PriorityClass) error {
+	if len(classes) == 0 {
+		return fmt.Errorf("at least one priority class is required")
+	}
+
+	names := make(map[string]bool)
+	roles := make(map[domain.RoleType]string)
+	defaults := 0
+	for _, class := range classes {
+		if class.Name == "" {
+			return fmt.Errorf("priority class name cannot be empty")
+		}
+		if names[class.Name] {
+			return fmt.Errorf("duplicate priority class: %s", class.Name)
+		}
+		names[class.Name] = true
+
+		for _, role := range class.Roles {
+			if other, exists := roles[role]; exists {
+				return fmt.Errorf("role %q mapped to both %s and %s", role, other, class.Name)
+			}
+			roles[role] = class.Name
+		}
+
+		if class.Default {
+			defaults++
+		}
+	}
+
+	if defaults > 1 {
+		return fmt.Errorf("only one priority class can be the default")
+	}
+
+	return nil
+}
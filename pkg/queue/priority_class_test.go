@@ -0,0 +1,143 @@
+package queue
+
+import (
+	"testing"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Roles used only by the tiered-queue tests
+const (
+	roleStaff    domain.RoleType = "Staff"
+	roleGold     domain.RoleType = "Gold Customer"
+	roleCatering domain.RoleType = "Catering Customer"
+)
+
+// fiveTierClasses returns the staff / VIP / gold / regular / catering tiers
+func fiveTierClasses(t *testing.T) []PriorityClass {
+	classes, err := ParsePriorityClasses(
+		"Staff=Staff;VIP=VIP Customer;Gold=Gold Customer;Regular=Regular Customer,*;Catering=Catering Customer")
+	require.NoError(t, err)
+	return classes
+}
+
+// TestParsePriorityClasses tests parsing of the class specification
+func TestParsePriorityClasses(t *testing.T) {
+	classes := fiveTierClasses(t)
+
+	require.Len(t, classes, 5, "Should parse five classes")
+	assert.Equal(t, "Staff", classes[0].Name, "Classes should keep specification order")
+	assert.Equal(t, []domain.RoleType{domain.RoleRegularCustomer}, classes[3].Roles)
+	assert.True(t, classes[3].Default, "'*' should mark the default class")
+	assert.False(t, classes[4].Default)
+}
+
+// TestParsePriorityClassesEmptyUsesDefaults tests that an empty spec keeps VIP/Regular
+func TestParsePriorityClassesEmptyUsesDefaults(t *testing.T) {
+	classes, err := ParsePriorityClasses("")
+
+	require.NoError(t, err)
+	assert.Equal(t, DefaultPriorityClasses(), classes)
+}
+
+// TestParsePriorityClassesInvalid tests that inconsistent specifications are rejected
+func TestParsePriorityClassesInvalid(t *testing.T) {
+	invalid := []string{
+		"VIP=VIP Customer;VIP=Regular Customer",         // duplicate class
+		"VIP=VIP Customer;Regular=VIP Customer",         // role in two classes
+		"VIP=VIP Customer,*;Regular=Regular Customer,*", // two defaults
+		"=VIP Customer", // empty name
+	}
+
+	for _, spec := range invalid {
+		_, err := ParsePriorityClasses(spec)
+		assert.Error(t, err, "Spec %q should be rejected", spec)
+	}
+}
+
+// TestTieredPriorityOrder tests that N classes are served in order with FIFO inside each class
+func TestTieredPriorityOrder(t *testing.T) {
+	pq := NewPriorityQueue(WithPriorityClasses(fiveTierClasses(t)))
+
+	// Enqueue lowest priority first to prove ordering is by class, not arrival
+	orders := []*domain.Order{
+		{ID: 1, CustomerRole: roleCatering},
+		{ID: 2, CustomerRole: domain.RoleRegularCustomer},
+		{ID: 3, CustomerRole: roleGold},
+		{ID: 4, CustomerRole: domain.RoleVIPCustomer},
+		{ID: 5, CustomerRole: roleStaff},
+		{ID: 6, CustomerRole: roleGold},
+		{ID: 7, CustomerRole: roleStaff},
+	}
+	for _, order := range orders {
+		require.NoError(t, pq.Enqueue(order))
+	}
+
+	expectedOrder := []int{5, 7, 4, 3, 6, 2, 1}
+	for _, expectedID := range expectedOrder {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, order.ID, "Orders should be dequeued by class, then FIFO")
+	}
+}
+
+// TestTieredUnknownRoleUsesDefaultClass tests that unmapped roles land in the default class
+func TestTieredUnknownRoleUsesDefaultClass(t *testing.T) {
+	pq := NewPriorityQueue(WithPriorityClasses(fiveTierClasses(t)))
+
+	unknown := &domain.Order{ID: 1, CustomerRole: "Mystery Shopper"}
+
+	assert.Equal(t, "Regular", pq.ClassOf(unknown), "Unmapped role should use the default class")
+}
+
+// TestTieredEnqueueAtFrontPerClass tests that EnqueueAtFront only jumps ahead within its own class
+func TestTieredEnqueueAtFrontPerClass(t *testing.T) {
+	pq := NewPriorityQueue(WithPriorityClasses(fiveTierClasses(t)))
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: roleGold}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: roleGold}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.EnqueueAtFront(&domain.Order{ID: 4, CustomerRole: roleGold}))
+
+	expectedOrder := []int{3, 4, 1, 2}
+	for _, expectedID := range expectedOrder {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, order.ID, "Front-queued Gold order should lead Gold but stay behind VIP")
+	}
+}
+
+// TestClassifierOverridesRole tests mapping orders to classes by order attributes
+func TestClassifierOverridesRole(t *testing.T) {
+	// Large orders are treated as catering regardless of the customer role
+	classifier := func(order *domain.Order) string {
+		if len(order.Foods) >= 3 {
+			return "Catering"
+		}
+		return ""
+	}
+	pq := NewPriorityQueue(WithPriorityClasses(fiveTierClasses(t)), WithClassifier(classifier))
+
+	bulk := &domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer, Foods: make([]domain.Food, 3)}
+	single := &domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer, Foods: make([]domain.Food, 1)}
+
+	assert.Equal(t, "Catering", pq.ClassOf(bulk), "Classifier should override the role mapping")
+	assert.Equal(t, "Regular", pq.ClassOf(single), "Empty classifier result should fall back to the role")
+
+	require.NoError(t, pq.Enqueue(bulk))
+	require.NoError(t, pq.Enqueue(single))
+
+	order, err := pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, single.ID, order.ID, "Regular order should precede the catering order")
+}
+
+// TestWithPriorityClassesPanicsOnInvalid tests that invalid programmatic classes are rejected
+func TestWithPriorityClassesPanicsOnInvalid(t *testing.T) {
+	assert.Panics(t, func() {
+		NewPriorityQueue(WithPriorityClasses(nil))
+	})
+}
@@ -1,7 +1,12 @@
 package queue
 
 import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
 
 	"mcmocknald-order-kiosk/internal/domain"
 )
@@ -9,19 +14,57 @@ import (
 // OrderQueue defines the interface for order queue operations
 // Following Interface Segregation Principle: focused interface for queue operations
 type OrderQueue interface {
+	// Admit checks that a new order fits within the queue's depth limits, without enqueuing it
+	// Returns a *QueueFullError (matching ErrQueueFull) when a limit is reached
+	// Only a pre-check: the limits may be reached before the order is enqueued (see AdmitAndEnqueue)
+	// Time Complexity: O(1)
+	Admit(order *domain.Order) error
+
+	// AdmitAndEnqueue checks the depth limits and enqueues a new order as one atomic step
+	// Returns a *QueueFullError (matching ErrQueueFull) without enqueuing when a limit is reached,
+	// so concurrent admissions never overshoot a limit
+	// Time Complexity: O(1)
+	AdmitAndEnqueue(order *domain.Order) error
+
 	// Enqueue adds an order to the queue
 	// Time Complexity: O(1) - appends to appropriate priority list
 	Enqueue(order *domain.Order) error
 
-	// Dequeue retrieves and removes the next order from the queue (VIP first, then FIFO)
+	// Dequeue retrieves and removes the next order from the queue (highest priority class first, then FIFO)
 	// Time Complexity: O(1) - retrieves from front of appropriate priority list
 	Dequeue() (*domain.Order, error)
 
+	// DequeueWait retrieves and removes the next order, blocking until one is available
+	// Returns ctx.Err() if the context is cancelled before an order arrives
+	// Time Complexity: O(1) per wake-up
+	DequeueWait(ctx context.Context) (*domain.Order, error)
+
+	// DequeueFor retrieves and removes the next order the capabilities can prepare
+	// Orders the cook cannot make are skipped and keep their place in line
+	// Returns ErrEmptyQueue if nothing is queued, ErrNoMatchingOrder if nothing queued fits
+	// Time Complexity: O(1) without capabilities, O(n) worst case when skipping unmatched orders
+	DequeueFor(caps Capabilities) (*domain.Order, error)
+
+	// DequeueWaitFor is DequeueWait restricted to orders the capabilities can prepare
+	// Time Complexity: O(1) without capabilities, O(n) worst case per wake-up
+	DequeueWaitFor(ctx context.Context, caps Capabilities) (*domain.Order, error)
+
 	// EnqueueAtFront adds an order to the front of its priority queue
 	// Used when a cook is removed and their order must be re-queued with priority
-	// Time Complexity: O(1) - prepends to appropriate priority list
+	// Time Complexity: O(1) - pushes to the front of the appropriate priority deque
 	EnqueueAtFront(order *domain.Order) error
 
+	// Remove takes a specific queued order out of the queue (e.g. cancelled or refunded)
+	// Returns ErrOrderNotFound if the order is not currently queued
+	// Time Complexity: O(1) - ID index lookup with lazy removal from the priority list
+	Remove(orderID int) (*domain.Order, error)
+
+	// Position returns the 1-based place in line of a queued order (1 = next to be served)
+	// Counts every order in higher priority classes plus those ahead of it in its own class
+	// Returns ErrOrderNotFound if the order is not currently queued
+	// Time Complexity: O(c + k) where k is the number of orders ahead in its class
+	Position(orderID int) (int, error)
+
 	// Size returns the total number of orders in the queue
 	// Time Complexity: O(1) - returns cached count
 	Size() int
@@ -33,32 +76,225 @@ type OrderQueue interface {
 	// Peek returns the next order without removing it
 	// Time Complexity: O(1) - returns first element without removal
 	Peek() (*domain.Order, error)
+
+	// Snapshot returns the queued orders of every priority class in service order within each class
+	// Time Complexity: O(n) where n is the number of queued orders
+	Snapshot() ([]ClassSnapshot, error)
+
+	// MoveToFront moves a queued order to the front of its priority class (expedite)
+	// Returns ErrOrderNotFound if the order is not currently queued
+	// Time Complexity: O(1) amortized
+	MoveToFront(orderID int) error
+
+	// SetClass moves a queued order to the back of another priority class
+	// The new class lasts while the order is queued; a re-queue classifies it again
+	// Returns ErrOrderNotFound if the order is not queued, ErrUnknownClass if the class does not exist
+	// Time Complexity: O(1) amortized
+	SetClass(orderID int, className string) error
+
+	// Subscribe returns a subscription to queue change events (enqueued, dequeued, requeued, removed)
+	// buffer is the number of undelivered events kept per subscriber before new ones are dropped
+	// Time Complexity: O(1)
+	Subscribe(buffer int) *Subscription
+}
+
+// ClassSnapshot lists the orders queued in one priority class, front first
+type ClassSnapshot struct {
+	Name   string        // Priority class name
+	Orders []QueuedOrder // Queued orders in service order
+}
+
+// QueuedOrder is a queued order with the time it entered its place in line
+type QueuedOrder struct {
+	Order      *domain.Order
+	EnqueuedAt time.Time
+}
+
+// queueItem wraps a queued order with the time it entered the queue (used for aging)
+type queueItem struct {
+	order      *domain.Order
+	enqueuedAt time.Time
+	seq        uint64 // Matches indexEntry.seq while the item is live; stale items are skipped
+	atFront    bool   // Put at the front of its class (EnqueueAtFront, MoveToFront) rather than the back
+}
+
+// indexEntry locates a live queued order by ID
+type indexEntry struct {
+	order *domain.Order
+	class int    // Index of the order's priority class
+	seq   uint64 // Sequence number of the live queueItem
+}
+
+// waiter is a parked DequeueWait caller and the orders it can take
+type waiter struct {
+	wake  chan struct{} // Closed when an order the waiter can take is enqueued
+	caps  Capabilities  // Food types the waiting cook can prepare (empty = every type)
+	order *domain.Order // Order that triggered the wake-up (handed on if the waiter gives up)
+}
+
+// classQueue holds the FIFO list of orders for a single priority class
+type classQueue struct {
+	name  string
+	items deque        // Ring-buffer deque: O(1) push/pop at both ends
+	due   deadlineHeap // Same items by deadline, kept only for deadline-ordered policies (EDF)
+	live  int          // Queued orders in this class (items.Len() minus removed tombstones)
 }
 
 // PriorityQueue implements a hybrid priority + FIFO queue
-// VIP orders are prioritized over Regular orders
-// Within each priority level, FIFO order is maintained
+// Orders are grouped into an ordered set of priority classes (default: VIP, then Regular)
+// Within each priority class, FIFO order is maintained
+// Which class is served next is decided by a SchedulingPolicy (default: strict priority)
 // Following Single Responsibility Principle: only manages order queue
 type PriorityQueue struct {
-	vipOrders     []*domain.Order // VIP customer orders (higher priority)
-	regularOrders []*domain.Order // Regular customer orders (lower priority)
-	mu            sync.RWMutex    // Protects concurrent access to the queue
-	size          int             // Cached total size for O(1) lookup
+	classes        []*classQueue           // Priority classes, highest priority first
+	roleIndex      map[domain.RoleType]int // Customer role -> class index
+	nameIndex      map[string]int          // Class name -> class index
+	defaultClass   int                     // Class for orders whose role matches no class
+	classifier     Classifier              // Optional attribute-based mapping (overrides roles)
+	mu             sync.RWMutex            // Protects concurrent access to the queue
+	size           int                     // Cached total size for O(1) lookup
+	index          map[int]indexEntry      // Order ID -> live queued item (O(1) Remove)
+	nextSeq        uint64                  // Sequence number for the next queued item
+	waiters        []*waiter               // Parked DequeueWait callers (FIFO), woken one per enqueue
+	policy         SchedulingPolicy        // Chooses the class to serve next
+	byDeadline     bool                    // Serve each class by deadline instead of FIFO (policy is a deadlineOrderer)
+	maxRegularWait time.Duration           // Aging bound set by WithMaxRegularWait (applied to the strict policy)
+	heads          []ClassHead             // Reusable buffer of class heads passed to the policy
+	limits         AdmissionLimits         // Depth limits checked by Admit and AdmitAndEnqueue (zero value = unlimited)
+	events         EventBus                // Change notifications for observers (never blocks)
+	now            func() time.Time        // Clock source (overridable in tests)
+}
+
+// Option configures optional PriorityQueue behaviour
+type Option func(*PriorityQueue)
+
+// WithPriorityClasses replaces the default VIP/Regular classes with an ordered set of classes
+// Panics if the classes are invalid; validate user input with ParsePriorityClasses first
+func WithPriorityClasses(classes []PriorityClass) Option {
+	return func(pq *PriorityQueue) {
+		if err := validatePriorityClasses(classes); err != nil {
+			panic(fmt.Sprintf("queue: invalid priority classes: %v", err))
+		}
+		pq.setClasses(classes)
+	}
+}
+
+// WithClassifier maps orders to classes by any order attribute (e.g. catering flag, order size)
+// The classifier returns a class name; unknown or empty names fall back to the role mapping
+func WithClassifier(classifier Classifier) Option {
+	return func(pq *PriorityQueue) {
+		pq.classifier = classifier
+	}
 }
 
 // NewPriorityQueue creates a new priority queue instance
+// Time Complexity: O(c) where c is the number of priority classes
+func NewPriorityQueue(opts ...Option) *PriorityQueue {
+	pq := &PriorityQueue{
+		size:   0,
+		index:  make(map[int]indexEntry),
+		policy: NewStrictPolicy(0),
+		now:    time.Now,
+	}
+	pq.setClasses(DefaultPriorityClasses())
+
+	for _, opt := range opts {
+		opt(pq)
+	}
+
+	// Aging is a strict-policy setting: configure it whatever order the options came in
+	if pq.maxRegularWait > 0 {
+		if _, strict := pq.policy.(*StrictPolicy); !strict {
+			panic(fmt.Sprintf("queue: invalid scheduling policy: aging (WithMaxRegularWait) only applies to the %s policy", PolicyStrict))
+		}
+		pq.policy = NewStrictPolicy(pq.maxRegularWait)
+	}
+
+	_, pq.byDeadline = pq.policy.(deadlineOrderer)
+
+	if validator, ok := pq.policy.(classCountValidator); ok {
+		if err := validator.validateClassCount(len(pq.classes)); err != nil {
+			panic(fmt.Sprintf("queue: invalid scheduling policy: %v", err))
+		}
+	}
+
+	if err := pq.limits.ValidateClassCount(len(pq.classes)); err != nil {
+		panic(fmt.Sprintf("queue: invalid admission limits: %v", err))
+	}
+
+	return pq
+}
+
+// setClasses (re)builds the class lists and lookup indexes
+// Time Complexity: O(c + r) where c is classes and r is mapped roles
+func (pq *PriorityQueue) setClasses(classes []PriorityClass) {
+	pq.classes = make([]*classQueue, len(classes))
+	pq.roleIndex = make(map[domain.RoleType]int)
+	pq.nameIndex = make(map[string]int)
+	pq.defaultClass = len(classes) - 1
+
+	for i, class := range classes {
+		pq.classes[i] = &classQueue{name: class.Name}
+		pq.nameIndex[class.Name] = i
+		for _, role := range class.Roles {
+			pq.roleIndex[role] = i
+		}
+		if class.Default {
+			pq.defaultClass = i
+		}
+	}
+}
+
+// classIndex resolves the priority class of an order
+// Time Complexity: O(1)
+func (pq *PriorityQueue) classIndex(order *domain.Order) int {
+	if pq.classifier != nil {
+		if idx, exists := pq.nameIndex[pq.classifier(order)]; exists {
+			return idx
+		}
+	}
+
+	if idx, exists := pq.roleIndex[order.CustomerRole]; exists {
+		return idx
+	}
+
+	return pq.defaultClass
+}
+
+// Classes returns the priority class names, highest priority first
+// Time Complexity: O(c) where c is the number of classes
+func (pq *PriorityQueue) Classes() []string {
+	names := make([]string, len(pq.classes))
+	for i, class := range pq.classes {
+		names[i] = class.name
+	}
+	return names
+}
+
+// ClassOf returns the name of the priority class an order maps to
+// Time Complexity: O(1)
+func (pq *PriorityQueue) ClassOf(order *domain.Order) string {
+	return pq.classes[pq.classIndex(order)].name
+}
+
+// Admit checks that a new order fits within the total and per-class depth limits
+// The check and a following Enqueue are not atomic: use AdmitAndEnqueue to enqueue within the limits
 // Time Complexity: O(1)
-func NewPriorityQueue() *PriorityQueue {
-	return &PriorityQueue{
-		vipOrders:     make([]*domain.Order, 0, 1000), // Pre-allocate for performance
-		regularOrders: make([]*domain.Order, 0, 1000), // Pre-allocate for performance
-		size:          0,
+func (pq *PriorityQueue) Admit(order *domain.Order) error {
+	if order == nil {
+		return ErrNilOrder
 	}
+
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	classIdx := pq.classIndex(order)
+	class := pq.classes[classIdx]
+	return pq.limits.Check(pq.size, class.name, classIdx, class.live)
 }
 
-// Enqueue adds an order to the appropriate queue based on customer type
-// VIP orders go to vipOrders, Regular orders go to regularOrders
-// Time Complexity: O(1) - append operation is amortized O(1)
+// Enqueue adds an order to the back of its priority class
+// Time Complexity: O(1) amortized - ring buffer push
 func (pq *PriorityQueue) Enqueue(order *domain.Order) error {
 	if order == nil {
 		return ErrNilOrder
@@ -67,64 +303,345 @@ func (pq *PriorityQueue) Enqueue(order *domain.Order) error {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
-	// Determine priority based on customer role
-	if order.CustomerRole == domain.RoleVIPCustomer {
-		pq.vipOrders = append(pq.vipOrders, order)
-	} else {
-		pq.regularOrders = append(pq.regularOrders, order)
+	if _, exists := pq.index[order.ID]; exists {
+		return ErrDuplicateOrder
 	}
 
-	pq.size++
+	// Determine priority class (attribute classifier, then customer role)
+	pq.enqueueLocked(order, pq.classIndex(order))
 	return nil
 }
 
+// AdmitAndEnqueue checks the depth limits and adds a new order to the back of its class under one lock
+// Time Complexity: O(1) amortized - ring buffer push
+func (pq *PriorityQueue) AdmitAndEnqueue(order *domain.Order) error {
+	if order == nil {
+		return ErrNilOrder
+	}
+
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	if _, exists := pq.index[order.ID]; exists {
+		return ErrDuplicateOrder
+	}
+
+	classIdx := pq.classIndex(order)
+	class := pq.classes[classIdx]
+	if err := pq.limits.Check(pq.size, class.name, classIdx, class.live); err != nil {
+		return err
+	}
+
+	pq.enqueueLocked(order, classIdx)
+	return nil
+}
+
+// enqueueLocked adds an order to the back of a class and wakes a waiter; caller must hold pq.mu
+// Time Complexity: O(1) amortized, O(log n) under EDF
+func (pq *PriorityQueue) enqueueLocked(order *domain.Order, classIdx int) {
+	class := pq.classes[classIdx]
+	pq.pushLocked(class, pq.trackLocked(order, classIdx, pq.now()), false)
+	class.live++
+
+	pq.size++
+	pq.signalLocked(order)
+	pq.publishLocked(EventEnqueued, order, classIdx)
+}
+
+// trackLocked registers an order in the ID index and returns its queue item; caller must hold pq.mu
+// Time Complexity: O(1)
+func (pq *PriorityQueue) trackLocked(order *domain.Order, classIdx int, enqueuedAt time.Time) queueItem {
+	pq.nextSeq++
+	pq.index[order.ID] = indexEntry{order: order, class: classIdx, seq: pq.nextSeq}
+	return queueItem{order: order, enqueuedAt: enqueuedAt, seq: pq.nextSeq}
+}
+
+// pushLocked adds a tracked item to the front or back of its class (and to its deadline heap under EDF)
+// Caller must hold pq.mu
+// Time Complexity: O(1) amortized, O(log n) under EDF
+func (pq *PriorityQueue) pushLocked(class *classQueue, item queueItem, front bool) {
+	item.atFront = front
+	if front {
+		class.items.PushFront(item)
+	} else {
+		class.items.PushBack(item)
+	}
+
+	if pq.byDeadline {
+		heap.Push(&class.due, item)
+	}
+}
+
+// headLocked returns the next live item of a non-empty class: its FIFO head, or its earliest deadline under EDF
+// Caller must hold the write lock (removed items are dropped from the deadline heap)
+// Time Complexity: O(1), O(log n) amortized under EDF
+func (pq *PriorityQueue) headLocked(class *classQueue) queueItem {
+	if !pq.byDeadline {
+		return class.items.Front() // Always live (see purgeHeadLocked)
+	}
+
+	for !pq.isLiveLocked(class.due[0]) {
+		heap.Pop(&class.due)
+	}
+	return class.due[0]
+}
+
+// takeHeadLocked removes and returns the next live item of a non-empty class (see headLocked)
+// Caller must hold pq.mu
+// Time Complexity: O(1) amortized, O(log n) amortized under EDF
+func (pq *PriorityQueue) takeHeadLocked(class *classQueue) queueItem {
+	var item queueItem
+	if pq.byDeadline {
+		item = pq.headLocked(class)
+		heap.Pop(&class.due)
+	} else {
+		item = class.items.PopFront()
+	}
+
+	class.live--
+	delete(pq.index, item.order.ID)
+	pq.purgeHeadLocked(class)
+	return item
+}
+
+// isLiveLocked reports whether an item is still queued (not removed); caller must hold pq.mu
+// Time Complexity: O(1)
+func (pq *PriorityQueue) isLiveLocked(item queueItem) bool {
+	entry, exists := pq.index[item.order.ID]
+	return exists && entry.seq == item.seq
+}
+
+// purgeHeadLocked drops removed items from the front of a class so its head is always live,
+// then compacts the class if removed items elsewhere have piled up (see compactLocked)
+// Every removed item is dropped exactly once, so this is O(1) amortized; caller must hold pq.mu
+func (pq *PriorityQueue) purgeHeadLocked(class *classQueue) {
+	for class.items.Len() > 0 && !pq.isLiveLocked(class.items.Front()) {
+		class.items.PopFront()
+	}
+	pq.compactLocked(class)
+}
+
+// compactLocked drops every removed item from a class once they outnumber its live items
+// Removed items behind the head (and, under EDF, orders taken through the deadline heap) are otherwise
+// only dropped when they surface, so the deque and heap are kept at most twice the live orders
+// Each compaction is paid for by the removed items it drops, so this is O(1) amortized; caller must hold pq.mu
+func (pq *PriorityQueue) compactLocked(class *classQueue) {
+	if class.items.Len()-class.live > class.live {
+		class.items.Retain(pq.isLiveLocked)
+	}
+	if pq.byDeadline && class.due.Len()-class.live > class.live {
+		class.due.Retain(pq.isLiveLocked)
+	}
+}
+
 // Dequeue retrieves and removes the next order from the queue
-// Priority: VIP orders first, then Regular orders (FIFO within each priority)
-// Time Complexity: O(1) - removes from front of slice (with slice re-slicing)
-// Note: Includes periodic memory optimization to prevent unbounded growth
+// The scheduling policy picks the class (default: highest non-empty class); FIFO within each class
+// Time Complexity: O(1) amortized - ring buffer pop (buffer shrinks as the queue drains)
 func (pq *PriorityQueue) Dequeue() (*domain.Order, error) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
+	return pq.dequeueLocked()
+}
+
+// DequeueWait retrieves and removes the next order, parking the caller until one is available
+// Each Enqueue wakes at most one parked caller, so idle workers consume no CPU
+// Time Complexity: O(1) per wake-up, O(w) on cancellation where w is the number of waiters
+func (pq *PriorityQueue) DequeueWait(ctx context.Context) (*domain.Order, error) {
+	return pq.DequeueWaitFor(ctx, nil)
+}
+
+// DequeueFor retrieves and removes the next order the capabilities can prepare
+// The scheduling policy chooses between the first matching order of each class
+// Time Complexity: O(1) amortized without capabilities, O(n) worst case with them
+func (pq *PriorityQueue) DequeueFor(caps Capabilities) (*domain.Order, error) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	return pq.dequeueForLocked(caps)
+}
+
+// DequeueWaitFor retrieves and removes the next order the capabilities can prepare, parking until one arrives
+// Enqueues only wake waiters that can prepare the new order, so a drink station sleeps through food orders
+// Time Complexity: O(n) worst case per wake-up, O(w) on cancellation where w is the number of waiters
+func (pq *PriorityQueue) DequeueWaitFor(ctx context.Context, caps Capabilities) (*domain.Order, error) {
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		pq.mu.Lock()
+		if pq.size > 0 {
+			order, err := pq.dequeueForLocked(caps)
+			if err != ErrNoMatchingOrder {
+				pq.mu.Unlock()
+				return order, err
+			}
+		}
+
+		// Register as a waiter while still holding the lock so no enqueue is missed
+		w := &waiter{wake: make(chan struct{}), caps: caps}
+		pq.waiters = append(pq.waiters, w)
+		pq.mu.Unlock()
+
+		select {
+		case <-w.wake:
+			// Woken by an enqueue - loop back and try to take the order
+		case <-ctx.Done():
+			pq.mu.Lock()
+			if !pq.removeWaiterLocked(w) {
+				// Already signalled: hand the wake-up to the next waiter so the order isn't stranded
+				pq.signalLocked(w.order)
+			}
+			pq.mu.Unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// dequeueForLocked removes the next order the capabilities can prepare; caller must hold pq.mu
+// Time Complexity: O(1) without capabilities, O(n) worst case with them
+func (pq *PriorityQueue) dequeueForLocked(caps Capabilities) (*domain.Order, error) {
+	if len(caps) == 0 {
+		return pq.dequeueLocked()
+	}
 	if pq.size == 0 {
 		return nil, ErrEmptyQueue
 	}
 
-	var order *domain.Order
-
-	// VIP orders have priority
-	if len(pq.vipOrders) > 0 {
-		order = pq.vipOrders[0]
-		pq.vipOrders[0] = nil // Clear reference for GC
-		pq.vipOrders = pq.vipOrders[1:] // Remove first element (FIFO)
+	// First live order each class can offer this cook (by FIFO position, or by deadline under EDF)
+	pq.heads = pq.heads[:0]
+	for i, class := range pq.classes {
+		var first queueItem
+		found := false
+		for j := 0; j < class.items.Len(); j++ {
+			item := class.items.At(j)
+			if !pq.isLiveLocked(item) || !caps.CanPrepare(item.order) {
+				continue
+			}
+			if !found || dueBefore(item, first) {
+				first, found = item, true
+			}
+			if !pq.byDeadline {
+				break
+			}
+		}
 
-		// Reset slice if wasted capacity exceeds threshold (prevent memory bloat)
-		if cap(pq.vipOrders)-len(pq.vipOrders) > 1000 {
-			newSlice := make([]*domain.Order, len(pq.vipOrders))
-			copy(newSlice, pq.vipOrders)
-			pq.vipOrders = newSlice
+		if found {
+			pq.heads = append(pq.heads, ClassHead{
+				Class:      i,
+				Order:      first.order,
+				EnqueuedAt: first.enqueuedAt,
+				Size:       class.live,
+			})
 		}
-	} else if len(pq.regularOrders) > 0 {
-		order = pq.regularOrders[0]
-		pq.regularOrders[0] = nil // Clear reference for GC
-		pq.regularOrders = pq.regularOrders[1:] // Remove first element (FIFO)
+	}
 
-		// Reset slice if wasted capacity exceeds threshold (prevent memory bloat)
-		if cap(pq.regularOrders)-len(pq.regularOrders) > 1000 {
-			newSlice := make([]*domain.Order, len(pq.regularOrders))
-			copy(newSlice, pq.regularOrders)
-			pq.regularOrders = newSlice
+	if len(pq.heads) == 0 {
+		return nil, ErrNoMatchingOrder
+	}
+
+	classIdx := pq.policy.Next(pq.heads, pq.now())
+	pq.policy.Served(classIdx)
+
+	var order *domain.Order
+	for _, head := range pq.heads {
+		if head.Class == classIdx {
+			order = head.Order
+			break
 		}
 	}
 
+	// Skipped orders keep their place: the taken item is tombstoned (popped now if it is the head)
+	class := pq.classes[classIdx]
+	delete(pq.index, order.ID)
+	class.live--
+	pq.purgeHeadLocked(class)
+
 	pq.size--
+	pq.publishLocked(EventDequeued, order, classIdx)
 	return order, nil
 }
 
-// EnqueueAtFront adds an order to the front of its priority queue
+// dequeueLocked removes the next order; caller must hold pq.mu
+// Time Complexity: O(1), O(log n) under EDF
+func (pq *PriorityQueue) dequeueLocked() (*domain.Order, error) {
+	if pq.size == 0 {
+		return nil, ErrEmptyQueue
+	}
+
+	classIdx := pq.nextClassLocked()
+	pq.policy.Served(classIdx)
+
+	item := pq.takeHeadLocked(pq.classes[classIdx])
+
+	pq.size--
+	pq.publishLocked(EventDequeued, item.order, classIdx)
+	return item.order, nil
+}
+
+// nextClassLocked returns the index of the class to serve next, or -1 if the queue is empty
+// Collects the head of every non-empty class and lets the scheduling policy choose
+// Caller must hold the write lock (the heads buffer is reused)
+// Time Complexity: O(c) where c is the number of classes (typically small and constant)
+func (pq *PriorityQueue) nextClassLocked() int {
+	pq.heads = pq.heads[:0]
+	for i, class := range pq.classes {
+		if class.live == 0 {
+			continue
+		}
+		head := pq.headLocked(class)
+		pq.heads = append(pq.heads, ClassHead{
+			Class:      i,
+			Order:      head.order,
+			EnqueuedAt: head.enqueuedAt,
+			Size:       class.live,
+		})
+	}
+
+	if len(pq.heads) == 0 {
+		return -1
+	}
+	return pq.policy.Next(pq.heads, pq.now())
+}
+
+// signalLocked wakes the longest-waiting DequeueWait caller that can prepare the order, if any
+// Caller must hold pq.mu
+// Time Complexity: O(1) when the first waiter matches, O(w) worst case where w is the number of waiters
+func (pq *PriorityQueue) signalLocked(order *domain.Order) {
+	for i, w := range pq.waiters {
+		if order != nil && !w.caps.CanPrepare(order) {
+			continue
+		}
+
+		if i == 0 {
+			pq.waiters[0] = nil // Clear reference for GC
+			pq.waiters = pq.waiters[1:]
+		} else {
+			pq.waiters = append(pq.waiters[:i], pq.waiters[i+1:]...)
+		}
+		w.order = order
+		close(w.wake)
+		return
+	}
+}
+
+// removeWaiterLocked unregisters a waiter; returns false if it was already signalled
+// Time Complexity: O(w) where w is the number of waiters
+func (pq *PriorityQueue) removeWaiterLocked(target *waiter) bool {
+	for i, w := range pq.waiters {
+		if w == target {
+			pq.waiters = append(pq.waiters[:i], pq.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// EnqueueAtFront adds an order to the front of its priority class
 // Used when a cook is removed and their order must be re-queued with priority (#1 position)
-// Time Complexity: O(n) where n is the size of the priority queue (due to slice prepend)
-// Note: This is acceptable as it's only called when a cook is removed, which is infrequent
+// The order inherits the head's enqueue time if older, so aging of the orders behind it is not delayed
+// Time Complexity: O(1) amortized - ring buffer push at the front
 func (pq *PriorityQueue) EnqueueAtFront(order *domain.Order) error {
 	if order == nil {
 		return ErrNilOrder
@@ -133,19 +650,192 @@ func (pq *PriorityQueue) EnqueueAtFront(order *domain.Order) error {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
-	// Determine priority based on customer role
-	if order.CustomerRole == domain.RoleVIPCustomer {
-		// Prepend to VIP orders
-		pq.vipOrders = append([]*domain.Order{order}, pq.vipOrders...)
-	} else {
-		// Prepend to Regular orders
-		pq.regularOrders = append([]*domain.Order{order}, pq.regularOrders...)
+	if _, exists := pq.index[order.ID]; exists {
+		return ErrDuplicateOrder
 	}
 
+	// Prepend to the order's priority class
+	classIdx := pq.classIndex(order)
+	class := pq.classes[classIdx]
+	pq.pushLocked(class, pq.trackLocked(order, classIdx, pq.frontEnqueuedAt(&class.items)), true)
+	class.live++
+
 	pq.size++
+	pq.signalLocked(order)
+	pq.publishLocked(EventRequeuedAtFront, order, classIdx)
+	return nil
+}
+
+// frontEnqueuedAt returns the enqueue time for a front insertion, keeping the list's head as its oldest entry
+// Time Complexity: O(1)
+func (pq *PriorityQueue) frontEnqueuedAt(items *deque) time.Time {
+	enqueuedAt := pq.now()
+	if items.Len() > 0 && items.Front().enqueuedAt.Before(enqueuedAt) {
+		enqueuedAt = items.Front().enqueuedAt
+	}
+	return enqueuedAt
+}
+
+// Remove takes a specific order out of the queue so it is never dequeued
+// The item is tombstoned via the ID index and dropped lazily once it reaches the head of its class
+// (or when the class is compacted)
+// Time Complexity: O(1) amortized
+func (pq *PriorityQueue) Remove(orderID int) (*domain.Order, error) {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	entry, exists := pq.index[orderID]
+	if !exists {
+		return nil, ErrOrderNotFound
+	}
+
+	class := pq.classes[entry.class]
+	delete(pq.index, orderID)
+	class.live--
+	pq.purgeHeadLocked(class)
+
+	pq.size--
+	pq.publishLocked(EventRemoved, entry.order, entry.class)
+	return entry.order, nil
+}
+
+// Subscribe returns a subscription to queue change events
+// Events are published while the queue lock is held, so they arrive in the order the changes happened
+// Time Complexity: O(1)
+func (pq *PriorityQueue) Subscribe(buffer int) *Subscription {
+	return pq.events.Subscribe(buffer)
+}
+
+// publishLocked notifies subscribers of a change; caller must hold pq.mu
+// Delivery never blocks: events for a subscriber with a full buffer are dropped
+// Time Complexity: O(s) where s is the number of subscribers
+func (pq *PriorityQueue) publishLocked(eventType EventType, order *domain.Order, classIdx int) {
+	pq.events.Publish(Event{
+		Type:  eventType,
+		Order: order,
+		Class: pq.classes[classIdx].name,
+		Size:  pq.size,
+		At:    pq.now(),
+	})
+}
+
+// Snapshot returns the queued orders of every priority class, front first (deadline order under EDF)
+// Time Complexity: O(n) where n is the number of queued items (including removed tombstones), O(n log n) under EDF
+func (pq *PriorityQueue) Snapshot() ([]ClassSnapshot, error) {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	snapshot := make([]ClassSnapshot, len(pq.classes))
+	for i, class := range pq.classes {
+		items := pq.liveItemsLocked(class)
+		snapshot[i] = ClassSnapshot{Name: class.name, Orders: make([]QueuedOrder, 0, len(items))}
+		for _, item := range items {
+			snapshot[i].Orders = append(snapshot[i].Orders, QueuedOrder{Order: item.order, EnqueuedAt: item.enqueuedAt})
+		}
+	}
+
+	return snapshot, nil
+}
+
+// liveItemsLocked returns the live items of a class in service order; caller must hold pq.mu
+// Time Complexity: O(n) where n is the number of items in the class, O(n log n) under EDF
+func (pq *PriorityQueue) liveItemsLocked(class *classQueue) []queueItem {
+	items := make([]queueItem, 0, class.live)
+	for j := 0; j < class.items.Len(); j++ {
+		if item := class.items.At(j); pq.isLiveLocked(item) {
+			items = append(items, item)
+		}
+	}
+
+	if pq.byDeadline {
+		sort.Slice(items, func(a, b int) bool { return dueBefore(items[a], items[b]) })
+	}
+	return items
+}
+
+// MoveToFront moves a queued order to the front of its priority class
+// The old item is tombstoned (dropped lazily or by compaction) and a new item is pushed at the front
+// Time Complexity: O(1) amortized
+func (pq *PriorityQueue) MoveToFront(orderID int) error {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	entry, exists := pq.index[orderID]
+	if !exists {
+		return ErrOrderNotFound
+	}
+
+	class := pq.classes[entry.class]
+	pq.pushLocked(class, pq.trackLocked(entry.order, entry.class, pq.frontEnqueuedAt(&class.items)), true)
+	pq.compactLocked(class)
+
+	pq.publishLocked(EventMovedToFront, entry.order, entry.class)
 	return nil
 }
 
+// SetClass moves a queued order to the back of another priority class
+// Time Complexity: O(1) amortized
+func (pq *PriorityQueue) SetClass(orderID int, className string) error {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
+
+	entry, exists := pq.index[orderID]
+	if !exists {
+		return ErrOrderNotFound
+	}
+
+	classIdx, exists := pq.nameIndex[className]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrUnknownClass, className)
+	}
+	if classIdx == entry.class {
+		return nil
+	}
+
+	// Tombstone the item in its old class
+	oldClass := pq.classes[entry.class]
+	delete(pq.index, orderID)
+	oldClass.live--
+	pq.purgeHeadLocked(oldClass)
+
+	newClass := pq.classes[classIdx]
+	pq.pushLocked(newClass, pq.trackLocked(entry.order, classIdx, pq.now()), false)
+	newClass.live++
+
+	pq.publishLocked(EventClassChanged, entry.order, classIdx)
+	return nil
+}
+
+// Position returns the 1-based place in line of a queued order (1 = next to be served)
+// Assumes strict class order: with aging enabled an old lower-class order may be served sooner
+// Within its class the order is placed by FIFO position, or by deadline under EDF
+// Time Complexity: O(c + n) where c is classes and n is the items in its class, O(c + n log n) under EDF
+func (pq *PriorityQueue) Position(orderID int) (int, error) {
+	pq.mu.RLock()
+	defer pq.mu.RUnlock()
+
+	entry, exists := pq.index[orderID]
+	if !exists {
+		return 0, ErrOrderNotFound
+	}
+
+	// Every order in a higher class is served first
+	position := 1
+	for _, class := range pq.classes[:entry.class] {
+		position += class.live
+	}
+
+	// Then the live orders ahead of it in its own class
+	for _, item := range pq.liveItemsLocked(pq.classes[entry.class]) {
+		if item.seq == entry.seq {
+			break
+		}
+		position++
+	}
+
+	return position, nil
+}
+
 // Size returns the total number of orders in the queue
 // Time Complexity: O(1) - returns cached count
 func (pq *PriorityQueue) Size() int {
@@ -163,23 +853,16 @@ func (pq *PriorityQueue) IsEmpty() bool {
 }
 
 // Peek returns the next order without removing it
-// Time Complexity: O(1) - returns first element without removal
+// Follows the same scheduling policy as Dequeue without advancing its state
+// Takes the write lock because the policy's heads buffer is shared
+// Time Complexity: O(c) where c is the number of classes
 func (pq *PriorityQueue) Peek() (*domain.Order, error) {
-	pq.mu.RLock()
-	defer pq.mu.RUnlock()
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
 
 	if pq.size == 0 {
 		return nil, ErrEmptyQueue
 	}
 
-	// VIP orders have priority
-	if len(pq.vipOrders) > 0 {
-		return pq.vipOrders[0], nil
-	}
-
-	if len(pq.regularOrders) > 0 {
-		return pq.regularOrders[0], nil
-	}
-
-	return nil, ErrEmptyQueue
+	return pq.headLocked(pq.classes[pq.nextClassLocked()]).order, nil
 }
@@ -0,0 +1,170 @@
+//go:build benchmark
+
+package queue
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"mcmocknald-order-kiosk/internal/domain"
+)
+
+// sliceQueue reproduces the previous slice-backed two-class queue so the
+// ring-buffer deque can be compared against it. Prepending copies the whole
+// class (O(n)) and popping re-slices with periodic compaction.
+type sliceQueue struct {
+	vip     []*domain.Order
+	regular []*domain.Order
+	mu      sync.Mutex
+}
+
+func newSliceQueue() *sliceQueue {
+	return &sliceQueue{
+		vip:     make([]*domain.Order, 0, 1000),
+		regular: make([]*domain.Order, 0, 1000),
+	}
+}
+
+func (q *sliceQueue) class(order *domain.Order) *[]*domain.Order {
+	if order.CustomerRole == domain.RoleVIPCustomer {
+		return &q.vip
+	}
+	return &q.regular
+}
+
+func (q *sliceQueue) Enqueue(order *domain.Order) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := q.class(order)
+	*items = append(*items, order)
+}
+
+func (q *sliceQueue) EnqueueAtFront(order *domain.Order) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := q.class(order)
+	*items = append([]*domain.Order{order}, *items...)
+}
+
+func (q *sliceQueue) Dequeue() *domain.Order {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	items := &q.vip
+	if len(q.vip) == 0 {
+		items = &q.regular
+	}
+	if len(*items) == 0 {
+		return nil
+	}
+	order := (*items)[0]
+	*items = (*items)[1:]
+	if cap(*items)-len(*items) > 1000 {
+		compacted := make([]*domain.Order, len(*items), len(*items)+1000)
+		copy(compacted, *items)
+		*items = compacted
+	}
+	return order
+}
+
+// benchQueue is the subset of operations exercised by the benchmarks
+type benchQueue interface {
+	Enqueue(order *domain.Order)
+	EnqueueAtFront(order *domain.Order)
+	Dequeue() *domain.Order
+}
+
+// dequeBenchQueue adapts PriorityQueue to benchQueue
+type dequeBenchQueue struct{ pq *PriorityQueue }
+
+func (q dequeBenchQueue) Enqueue(order *domain.Order)        { _ = q.pq.Enqueue(order) }
+func (q dequeBenchQueue) EnqueueAtFront(order *domain.Order) { _ = q.pq.EnqueueAtFront(order) }
+func (q dequeBenchQueue) Dequeue() *domain.Order {
+	order, _ := q.pq.Dequeue()
+	return order
+}
+
+var benchImplementations = []struct {
+	name string
+	new  func() benchQueue
+}{
+	{"Deque", func() benchQueue { return dequeBenchQueue{NewPriorityQueue()} }},
+	{"Slice", func() benchQueue { return newSliceQueue() }},
+}
+
+var benchDepths = []int{100, 10000, 100000}
+
+func benchOrder(id int) *domain.Order {
+	role := domain.RoleRegularCustomer
+	if id%3 == 0 {
+		role = domain.RoleVIPCustomer
+	}
+	return &domain.Order{ID: id, CustomerRole: role}
+}
+
+func fillQueue(q benchQueue, depth int) {
+	for i := 0; i < depth; i++ {
+		q.Enqueue(benchOrder(i))
+	}
+}
+
+// BenchmarkEnqueueAtFront measures re-queuing at the front of an already deep queue
+func BenchmarkEnqueueAtFront(b *testing.B) {
+	for _, impl := range benchImplementations {
+		for _, depth := range benchDepths {
+			b.Run(impl.name+"/depth="+strconv.Itoa(depth), func(b *testing.B) {
+				q := impl.new()
+				fillQueue(q, depth)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					// Re-queue the order just taken (as when its cook is removed), keeping the depth steady
+					q.EnqueueAtFront(q.Dequeue())
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkEnqueueDequeue measures steady-state throughput at a fixed queue depth
+func BenchmarkEnqueueDequeue(b *testing.B) {
+	for _, impl := range benchImplementations {
+		for _, depth := range benchDepths {
+			b.Run(impl.name+"/depth="+strconv.Itoa(depth), func(b *testing.B) {
+				q := impl.new()
+				fillQueue(q, depth)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					q.Enqueue(benchOrder(i))
+					q.Dequeue()
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkFillAndDrain measures a burst of orders followed by a full drain
+func BenchmarkFillAndDrain(b *testing.B) {
+	for _, impl := range benchImplementations {
+		for _, depth := range benchDepths {
+			b.Run(impl.name+"/depth="+strconv.Itoa(depth), func(b *testing.B) {
+				orders := make([]*domain.Order, depth)
+				for i := range orders {
+					orders[i] = benchOrder(i)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					q := impl.new()
+					for _, order := range orders {
+						q.Enqueue(order)
+					}
+					for range orders {
+						q.Dequeue()
+					}
+				}
+			})
+		}
+	}
+}
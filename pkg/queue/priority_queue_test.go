@@ -1,8 +1,10 @@
 package queue
 
 import (
+	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"mcmocknald-order-kiosk/internal/domain"
 
@@ -403,3 +405,379 @@ func TestMixedConcurrentOperations(t *testing.T) {
 	size := pq.Size()
 	assert.GreaterOrEqual(t, size, 0, "Size should never be negative")
 }
+
+// TestDequeueWaitReturnsQueuedOrder tests that DequeueWait returns immediately when orders exist
+func TestDequeueWaitReturnsQueuedOrder(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	expected := &domain.Order{
+		ID:           1,
+		CustomerRole: domain.RoleRegularCustomer,
+		Status:       domain.OrderStatusPending,
+	}
+	require.NoError(t, pq.Enqueue(expected))
+
+	order, err := pq.DequeueWait(context.Background())
+
+	require.NoError(t, err, "DequeueWait should not return error")
+	assert.Equal(t, expected.ID, order.ID, "Should dequeue the queued order")
+	assert.True(t, pq.IsEmpty(), "Queue should be empty after dequeue")
+}
+
+// TestDequeueWaitBlocksUntilEnqueue tests that a parked waiter wakes as soon as an order arrives
+func TestDequeueWaitBlocksUntilEnqueue(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	result := make(chan *domain.Order, 1)
+	go func() {
+		order, err := pq.DequeueWait(context.Background())
+		if err == nil {
+			result <- order
+		}
+	}()
+
+	// Waiter should still be parked while the queue is empty
+	select {
+	case <-result:
+		t.Fatal("DequeueWait returned before any order was enqueued")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	expected := &domain.Order{
+		ID:           1,
+		CustomerRole: domain.RoleVIPCustomer,
+		Status:       domain.OrderStatusPending,
+	}
+	require.NoError(t, pq.Enqueue(expected))
+
+	select {
+	case order := <-result:
+		assert.Equal(t, expected.ID, order.ID, "Waiter should receive the enqueued order")
+	case <-time.After(time.Second):
+		t.Fatal("DequeueWait did not wake after enqueue")
+	}
+}
+
+// TestDequeueWaitContextCancelled tests that cancelling the context releases a parked waiter
+func TestDequeueWaitContextCancelled(t *testing.T) {
+	pq := NewPriorityQueue()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := pq.DequeueWait(ctx)
+		errCh <- err
+	}()
+
+	cancel()
+
+	select {
+	case err := <-errCh:
+		assert.ErrorIs(t, err, context.Canceled, "Should return context error")
+	case <-time.After(time.Second):
+		t.Fatal("DequeueWait did not return after cancellation")
+	}
+
+	// A cancelled waiter must not swallow later orders
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	assert.Equal(t, 1, pq.Size(), "Order should remain queued")
+}
+
+// TestDequeueWaitManyWaiters tests that every enqueued order reaches exactly one parked waiter
+func TestDequeueWaitManyWaiters(t *testing.T) {
+	pq := NewPriorityQueue()
+	const numWaiters = 50
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	received := make(map[int]bool)
+
+	for i := 0; i < numWaiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			order, err := pq.DequeueWait(ctx)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			received[order.ID] = true
+			mu.Unlock()
+		}()
+	}
+
+	for i := 1; i <= numWaiters; i++ {
+		require.NoError(t, pq.Enqueue(&domain.Order{ID: i, CustomerRole: domain.RoleRegularCustomer}))
+	}
+
+	wg.Wait()
+
+	assert.Len(t, received, numWaiters, "Each order should be delivered to exactly one waiter")
+	assert.True(t, pq.IsEmpty(), "Queue should be drained")
+}
+
+// fakeClock is a manually advanced clock for deterministic aging tests
+type fakeClock struct {
+	current time.Time
+}
+
+func (c *fakeClock) Now() time.Time          { return c.current }
+func (c *fakeClock) Advance(d time.Duration) { c.current = c.current.Add(d) }
+func newFakeClock() *fakeClock               { return &fakeClock{current: time.Unix(0, 0)} }
+
+// TestAgingDisabledByDefault tests that without aging VIP orders always win
+func TestAgingDisabledByDefault(t *testing.T) {
+	clock := newFakeClock()
+	pq := NewPriorityQueue()
+	pq.now = clock.Now
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	clock.Advance(time.Hour)
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer}))
+
+	order, err := pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 2, order.ID, "VIP order should be dequeued first when aging is disabled")
+}
+
+// TestAgingPromotesStarvedRegularOrder tests that a Regular order is served once it reaches the wait bound
+func TestAgingPromotesStarvedRegularOrder(t *testing.T) {
+	clock := newFakeClock()
+	pq := NewPriorityQueue(WithMaxRegularWait(30 * time.Second))
+	pq.now = clock.Now
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}))
+
+	// Before the bound, VIP orders are still preferred
+	clock.Advance(29 * time.Second)
+	order, err := pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 2, order.ID, "VIP order should win before the Regular order has aged")
+
+	// At the bound, the Regular order jumps ahead of the remaining VIP order
+	clock.Advance(time.Second)
+	peeked, err := pq.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 1, peeked.ID, "Peek should reflect aging")
+
+	order, err = pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.ID, "Aged Regular order should be dequeued ahead of VIP")
+
+	order, err = pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 3, order.ID, "Remaining VIP order should follow")
+}
+
+// TestAgingBoundsRegularWaitUnderSustainedVIPLoad simulates a kitchen saturated by VIP orders
+// One VIP order arrives and one order is served every second; a Regular order arrives every 5 seconds
+// No Regular order may wait longer than the configured bound, and VIP orders must still be served first otherwise
+func TestAgingBoundsRegularWaitUnderSustainedVIPLoad(t *testing.T) {
+	const (
+		maxWait = 10 * time.Second
+		tick    = time.Second
+		ticks   = 300
+	)
+
+	clock := newFakeClock()
+	pq := NewPriorityQueue(WithMaxRegularWait(maxWait))
+	pq.now = clock.Now
+
+	enqueuedAt := make(map[int]time.Time)
+	nextID := 1
+	regularServed, vipServed := 0, 0
+
+	for i := 0; i < ticks; i++ {
+		vip := &domain.Order{ID: nextID, CustomerRole: domain.RoleVIPCustomer}
+		nextID++
+		require.NoError(t, pq.Enqueue(vip))
+
+		if i%5 == 0 {
+			regular := &domain.Order{ID: nextID, CustomerRole: domain.RoleRegularCustomer}
+			nextID++
+			enqueuedAt[regular.ID] = clock.Now()
+			require.NoError(t, pq.Enqueue(regular))
+		}
+
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+
+		if order.CustomerRole == domain.RoleRegularCustomer {
+			regularServed++
+			wait := clock.Now().Sub(enqueuedAt[order.ID])
+			assert.LessOrEqual(t, wait, maxWait, "Regular order %d waited longer than the bound", order.ID)
+			assert.GreaterOrEqual(t, wait, maxWait, "Regular order %d should not jump VIP orders before aging", order.ID)
+		} else {
+			vipServed++
+		}
+
+		clock.Advance(tick)
+	}
+
+	assert.Greater(t, regularServed, 0, "Regular orders must not starve")
+	assert.Greater(t, vipServed, regularServed, "VIP orders should still be preferred")
+}
+
+// TestEnqueueAtFrontPreservesAging tests that a requeued order does not reset aging for orders behind it
+func TestEnqueueAtFrontPreservesAging(t *testing.T) {
+	clock := newFakeClock()
+	pq := NewPriorityQueue(WithMaxRegularWait(10 * time.Second))
+	pq.now = clock.Now
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer}))
+
+	clock.Advance(10 * time.Second)
+	require.NoError(t, pq.EnqueueAtFront(&domain.Order{ID: 3, CustomerRole: domain.RoleRegularCustomer}))
+
+	order, err := pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 3, order.ID, "Requeued Regular order inherits the head's age and is served first")
+
+	order, err = pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.ID, "Aged Regular order should still be served ahead of VIP")
+}
+
+// TestMaxRegularWaitRequiresStrictPolicy tests that aging neither replaces nor is dropped by another policy
+func TestMaxRegularWaitRequiresStrictPolicy(t *testing.T) {
+	assert.Panics(t, func() {
+		NewPriorityQueue(WithSchedulingPolicy(NewEDFPolicy()), WithMaxRegularWait(time.Minute))
+	}, "Aging after a non-strict policy should be rejected")
+	assert.Panics(t, func() {
+		NewPriorityQueue(WithMaxRegularWait(time.Minute), WithWeightedRoundRobin([]int{3, 1}))
+	}, "A non-strict policy after aging should be rejected")
+
+	// Aging configures the strict policy whichever option comes first
+	clock := newFakeClock()
+	pq := NewPriorityQueue(WithMaxRegularWait(30*time.Second), WithSchedulingPolicy(NewStrictPolicy(0)))
+	pq.now = clock.Now
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer}))
+	clock.Advance(30 * time.Second)
+
+	order, err := pq.Dequeue()
+	require.NoError(t, err)
+	assert.Equal(t, 1, order.ID, "Aged Regular order should be dequeued ahead of VIP")
+}
+
+// TestRemove tests removing a queued order from the middle of its class
+func TestRemove(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, pq.Enqueue(&domain.Order{ID: i, CustomerRole: domain.RoleRegularCustomer}))
+	}
+
+	removed, err := pq.Remove(2)
+	require.NoError(t, err)
+	assert.Equal(t, 2, removed.ID)
+	assert.Equal(t, 2, pq.Size(), "Size should exclude the removed order")
+
+	for _, expectedID := range []int{1, 3} {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, order.ID, "Removed order should be skipped")
+	}
+	assert.True(t, pq.IsEmpty())
+}
+
+// TestRemoveHeadUpdatesPeek tests that removing a class head exposes the next live order
+func TestRemoveHeadUpdatesPeek(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer}))
+
+	_, err := pq.Remove(1)
+	require.NoError(t, err)
+
+	order, err := pq.Peek()
+	require.NoError(t, err)
+	assert.Equal(t, 2, order.ID, "Empty VIP class should fall through to Regular")
+}
+
+// TestRemoveNotQueued tests removing an unknown or already dequeued order
+func TestRemoveNotQueued(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	_, err := pq.Remove(99)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	_, err = pq.Dequeue()
+	require.NoError(t, err)
+
+	_, err = pq.Remove(1)
+	assert.ErrorIs(t, err, ErrOrderNotFound, "Dequeued order is no longer removable")
+
+	_, err = pq.Remove(1)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
+
+// TestRemoveThenRequeue tests that a removed order can be queued again without its stale entry resurfacing
+func TestRemoveThenRequeue(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	order1 := &domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}
+	order2 := &domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer}
+	require.NoError(t, pq.Enqueue(order2))
+	require.NoError(t, pq.Enqueue(order1))
+
+	_, err := pq.Remove(1)
+	require.NoError(t, err)
+	require.NoError(t, pq.EnqueueAtFront(order1))
+	assert.Equal(t, 2, pq.Size())
+
+	for _, expectedID := range []int{1, 2} {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, expectedID, order.ID)
+	}
+	_, err = pq.Dequeue()
+	assert.ErrorIs(t, err, ErrEmptyQueue, "Stale entry for the removed order must not be dequeued")
+}
+
+// TestEnqueueDuplicateOrder tests that an order cannot be queued twice
+func TestEnqueueDuplicateOrder(t *testing.T) {
+	pq := NewPriorityQueue()
+	order := &domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer}
+
+	require.NoError(t, pq.Enqueue(order))
+	assert.ErrorIs(t, pq.Enqueue(order), ErrDuplicateOrder)
+	assert.ErrorIs(t, pq.EnqueueAtFront(order), ErrDuplicateOrder)
+	assert.Equal(t, 1, pq.Size())
+}
+
+// TestPosition tests that position counts higher-class orders and same-class orders ahead
+func TestPosition(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 4, CustomerRole: domain.RoleVIPCustomer}))
+
+	expected := map[int]int{3: 1, 4: 2, 1: 3, 2: 4}
+	for orderID, want := range expected {
+		position, err := pq.Position(orderID)
+		require.NoError(t, err)
+		assert.Equal(t, want, position, "Unexpected position for order %d", orderID)
+	}
+
+	// Removed orders no longer count towards positions behind them
+	_, err := pq.Remove(1)
+	require.NoError(t, err)
+	position, err := pq.Position(2)
+	require.NoError(t, err)
+	assert.Equal(t, 3, position)
+
+	_, err = pq.Position(1)
+	assert.ErrorIs(t, err, ErrOrderNotFound)
+}
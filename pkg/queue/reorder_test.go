@@ -0,0 +1,98 @@
+package queue
+
+import (
+	"testing"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// snapshotIDs returns the order IDs of each class in a snapshot
+func snapshotIDs(t *testing.T, pq *PriorityQueue) map[string][]int {
+	snapshot, err := pq.Snapshot()
+	require.NoError(t, err)
+
+	ids := make(map[string][]int)
+	for _, class := range snapshot {
+		ids[class.Name] = []int{}
+		for _, queued := range class.Orders {
+			ids[class.Name] = append(ids[class.Name], queued.Order.ID)
+		}
+	}
+	return ids
+}
+
+// TestSnapshotListsClassesInServiceOrder tests that snapshots skip removed orders and keep FIFO order
+func TestSnapshotListsClassesInServiceOrder(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 4, CustomerRole: domain.RoleRegularCustomer}))
+	_, err := pq.Remove(3)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]int{"VIP": {2}, "Regular": {1, 4}}, snapshotIDs(t, pq))
+}
+
+// TestMoveToFront tests that an expedited order is served first in its class
+func TestMoveToFront(t *testing.T) {
+	pq := NewPriorityQueue()
+	sub := pq.Subscribe(10)
+	defer sub.Close()
+
+	for id := 1; id <= 3; id++ {
+		require.NoError(t, pq.Enqueue(&domain.Order{ID: id, CustomerRole: domain.RoleRegularCustomer}))
+	}
+
+	require.NoError(t, pq.MoveToFront(3))
+	assert.Equal(t, []int{3, 1, 2}, snapshotIDs(t, pq)["Regular"])
+	assert.Equal(t, 3, pq.Size(), "Moving must not change the size")
+
+	position, err := pq.Position(3)
+	require.NoError(t, err)
+	assert.Equal(t, 1, position)
+
+	for _, want := range []int{3, 1, 2} {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, want, order.ID)
+	}
+	assert.True(t, pq.IsEmpty(), "Tombstoned item must not be served again")
+
+	assert.ErrorIs(t, pq.MoveToFront(3), ErrOrderNotFound)
+
+	// 3 enqueues, then the move
+	for i := 0; i < 3; i++ {
+		receive(t, sub)
+	}
+	assert.Equal(t, EventMovedToFront, receive(t, sub).Type)
+}
+
+// TestSetClass tests moving a queued order to the back of another class
+func TestSetClass(t *testing.T) {
+	pq := NewPriorityQueue()
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleRegularCustomer}))
+
+	require.NoError(t, pq.SetClass(3, "VIP"))
+	assert.Equal(t, map[string][]int{"VIP": {1, 3}, "Regular": {2}}, snapshotIDs(t, pq))
+	assert.Equal(t, 3, pq.Size())
+
+	require.NoError(t, pq.SetClass(3, "VIP"), "Moving to the current class is a no-op")
+	assert.ErrorIs(t, pq.SetClass(3, "Platinum"), ErrUnknownClass)
+	assert.ErrorIs(t, pq.SetClass(99, "VIP"), ErrOrderNotFound)
+
+	var ids []int
+	for !pq.IsEmpty() {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		ids = append(ids, order.ID)
+	}
+	assert.Equal(t, []int{1, 3, 2}, ids)
+}
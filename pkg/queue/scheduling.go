@@ -0,0 +1,178 @@
+package queue
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"mcmocknald-order-kiosk/internal/domain"
+)
+
+// Scheduling policy names (selected at startup via configuration)
+const (
+	PolicyStrict   = "strict"   // Highest non-empty class first (optionally with aging)
+	PolicyWeighted = "weighted" // Weighted round-robin across classes (e.g. 3 VIP : 1 Regular)
+	PolicyEDF      = "edf"      // Earliest SLA deadline first across class heads
+)
+
+// DefaultClassWeights is the weighted round-robin ratio for the default VIP/Regular classes
+const DefaultClassWeights = "3,1"
+
+// ClassHead is the front order of a non-empty priority class, as seen by a SchedulingPolicy
+type ClassHead struct {
+	Class      int           // Class index (0 = highest priority)
+	Order      *domain.Order // Next order in the class (FIFO head, or earliest deadline for a deadlineOrderer)
+	EnqueuedAt time.Time     // When the head entered the queue
+	Size       int           // Number of orders queued in the class
+}
+
+// SchedulingPolicy decides which priority class is served next
+// The queue keeps FIFO order within each class (deadline order for a deadlineOrderer); the policy only
+// chooses between class heads
+// Following Strategy Pattern: ordering rules are swappable without forking PriorityQueue
+type SchedulingPolicy interface {
+	// Next returns the class index to serve from the heads of the non-empty classes
+	// heads is never empty and is sorted by class (highest priority first)
+	// Must not change policy state: it is also used by Peek
+	Next(heads []ClassHead, now time.Time) int
+
+	// Served records that an order from the given class was dequeued (for stateful policies)
+	Served(class int)
+}
+
+// classCountValidator is implemented by policies whose settings depend on the number of classes
+type classCountValidator interface {
+	validateClassCount(classes int) error
+}
+
+// deadlineOrderer is implemented by policies that need each class served by deadline rather than FIFO
+// The queue then keeps a deadline heap per class and offers its earliest order as the class head
+type deadlineOrderer interface {
+	ordersByDeadline()
+}
+
+// PolicySettings holds the configuration any built-in policy may need
+type PolicySettings struct {
+	MaxWait time.Duration // Strict: anti-starvation aging bound for lower classes (0 disables)
+	Weights []int         // Weighted: orders per turn for each class, highest first
+}
+
+// PolicyFactory builds a scheduling policy from settings
+type PolicyFactory func(settings PolicySettings) (SchedulingPolicy, error)
+
+var (
+	policyMu        sync.RWMutex
+	policyFactories = map[string]PolicyFactory{
+		PolicyStrict: func(settings PolicySettings) (SchedulingPolicy, error) {
+			if settings.MaxWait < 0 {
+				return nil, fmt.Errorf("max wait must be non-negative")
+			}
+			return NewStrictPolicy(settings.MaxWait), nil
+		},
+		PolicyWeighted: func(settings PolicySettings) (SchedulingPolicy, error) {
+			if settings.MaxWait > 0 {
+				return nil, fmt.Errorf("aging only applies to the %s policy", PolicyStrict)
+			}
+			if err := validateClassWeights(settings.Weights); err != nil {
+				return nil, err
+			}
+			return NewWeightedPolicy(settings.Weights), nil
+		},
+		PolicyEDF: func(settings PolicySettings) (SchedulingPolicy, error) {
+			if settings.MaxWait > 0 {
+				return nil, fmt.Errorf("aging only applies to the %s policy", PolicyStrict)
+			}
+			return NewEDFPolicy(), nil
+		},
+	}
+)
+
+// RegisterSchedulingPolicy makes a policy selectable by name (e.g. from configuration)
+// Registering an existing name replaces it
+func RegisterSchedulingPolicy(name string, factory PolicyFactory) {
+	policyMu.Lock()
+	defer policyMu.Unlock()
+	policyFactories[name] = factory
+}
+
+// NewSchedulingPolicy builds the policy registered under name
+// Time Complexity: O(1)
+func NewSchedulingPolicy(name string, settings PolicySettings) (SchedulingPolicy, error) {
+	policyMu.RLock()
+	factory, exists := policyFactories[name]
+	policyMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("unknown scheduling policy %q (available: %s)",
+			name, strings.Join(SchedulingPolicies(), ", "))
+	}
+
+	policy, err := factory(settings)
+	if err != nil {
+		return nil, fmt.Errorf("scheduling policy %s: %w", name, err)
+	}
+	return policy, nil
+}
+
+// SchedulingPolicies returns the registered policy names in sorted order
+// Time Complexity: O(p log p) where p is the number of policies
+func SchedulingPolicies() []string {
+	policyMu.RLock()
+	defer policyMu.RUnlock()
+
+	names := make([]string, 0, len(policyFactories))
+	for name := range policyFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithSchedulingPolicy sets the policy that chooses between priority classes (default: strict)
+// NewPriorityQueue panics if the policy's settings do not fit the configured classes
+func WithSchedulingPolicy(policy SchedulingPolicy) Option {
+	return func(pq *PriorityQueue) {
+		pq.policy = policy
+	}
+}
+
+// ParseClassWeights parses a comma-separated weight per priority class (highest class first)
+// Format: "3,1" - three orders from the first class, then one from the second
+// Time Complexity: O(n) where n is the length of the specification
+func ParseClassWeights(spec string) ([]int, error) {
+	var weights []int
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		weight, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid weight %q: %w", part, err)
+		}
+		weights = append(weights, weight)
+	}
+
+	if err := validateClassWeights(weights); err != nil {
+		return nil, err
+	}
+
+	return weights, nil
+}
+
+// validateClassWeights checks at least one weight is given and every weight is positive
+// Time Complexity: O(n) where n is the number of weights
+func validateClassWeights(weights []int) error {
+	if len(weights) == 0 {
+		return fmt.Errorf("at least one class weight is required")
+	}
+	for _, weight := range weights {
+		if weight <= 0 {
+			return fmt.Errorf("class weights must be positive (got %d)", weight)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package queue
+
+import (
+	"testing"
+
+	"mcmocknald-order-kiosk/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// drainRoles dequeues n orders and returns their customer roles in service order
+func drainRoles(t *testing.T, pq *PriorityQueue, n int) []domain.RoleType {
+	roles := make([]domain.RoleType, 0, n)
+	for i := 0; i < n; i++ {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		roles = append(roles, order.CustomerRole)
+	}
+	return roles
+}
+
+// TestParseClassWeights tests parsing of the weight specification
+func TestParseClassWeights(t *testing.T) {
+	weights, err := ParseClassWeights(" 3, 1 ")
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 1}, weights)
+
+	for _, spec := range []string{"", "3,x", "3,0", "-1,1"} {
+		_, err := ParseClassWeights(spec)
+		assert.Error(t, err, "spec %q should be rejected", spec)
+	}
+}
+
+// TestWeightedRoundRobinRatio tests that classes are served 3:1 while both are waiting
+func TestWeightedRoundRobinRatio(t *testing.T) {
+	pq := NewPriorityQueue(WithWeightedRoundRobin([]int{3, 1}))
+
+	id := 0
+	for i := 0; i < 9; i++ {
+		id++
+		require.NoError(t, pq.Enqueue(&domain.Order{ID: id, CustomerRole: domain.RoleVIPCustomer}))
+	}
+	for i := 0; i < 5; i++ {
+		id++
+		require.NoError(t, pq.Enqueue(&domain.Order{ID: id, CustomerRole: domain.RoleRegularCustomer}))
+	}
+
+	vip, regular := domain.RoleVIPCustomer, domain.RoleRegularCustomer
+	expected := []domain.RoleType{
+		vip, vip, vip, regular,
+		vip, vip, vip, regular,
+		vip, vip, vip, regular,
+		regular, regular, // VIP drained: Regular is served alone
+	}
+	assert.Equal(t, expected, drainRoles(t, pq, len(expected)))
+}
+
+// TestWeightedRoundRobinKeepsFIFOWithinClass tests FIFO order inside each class
+func TestWeightedRoundRobinKeepsFIFOWithinClass(t *testing.T) {
+	pq := NewPriorityQueue(WithWeightedRoundRobin([]int{1, 1}))
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleRegularCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 4, CustomerRole: domain.RoleVIPCustomer}))
+
+	var ids []int
+	for !pq.IsEmpty() {
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		ids = append(ids, order.ID)
+	}
+	assert.Equal(t, []int{3, 1, 4, 2}, ids)
+}
+
+// TestWeightedRoundRobinPeekDoesNotAdvance tests that Peek matches Dequeue without consuming a turn
+func TestWeightedRoundRobinPeekDoesNotAdvance(t *testing.T) {
+	pq := NewPriorityQueue(WithWeightedRoundRobin([]int{1, 1}))
+
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 1, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 2, CustomerRole: domain.RoleVIPCustomer}))
+	require.NoError(t, pq.Enqueue(&domain.Order{ID: 3, CustomerRole: domain.RoleRegularCustomer}))
+
+	for i := 0; i < 3; i++ {
+		peeked, err := pq.Peek()
+		require.NoError(t, err)
+		assert.Equal(t, 1, peeked.ID, "Repeated Peek should not change the next order")
+	}
+
+	for _, expectedID := range []int{1, 3, 2} {
+		peeked, err := pq.Peek()
+		require.NoError(t, err)
+		order, err := pq.Dequeue()
+		require.NoError(t, err)
+		assert.Equal(t, peeked.ID, order.ID)
+		assert.Equal(t, expectedID, order.ID)
+	}
+}
+
+// TestWeightedRoundRobinWeightMismatchPanics tests that weights must match the priority classes
+func TestWeightedRoundRobinWeightMismatchPanics(t *testing.T) {
+	assert.Panics(t, func() {
+		NewPriorityQueue(WithWeightedRoundRobin([]int{3, 2, 1}))
+	})
+	assert.Panics(t, func() {
+		NewPriorityQueue(WithWeightedRoundRobin([]int{0, 1}))
+	})
+}
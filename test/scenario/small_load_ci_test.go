@@ -67,8 +67,8 @@ func TestSmallLoadCI(t *testing.T) {
 
 	// Initialize services
 	orderQueue := queue.NewPriorityQueue()
-	orderService := service.NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, ciSmallServingDuration)
-	cookService := service.NewCookService(userRepo, orderRepo, orderQueue, log, ciSmallServingDuration)
+	orderService := service.NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, service.OrderServiceConfig{ServingDuration: ciSmallServingDuration})
+	cookService := service.NewCookService(userRepo, orderRepo, orderQueue, log, service.CookServiceConfig{ServingDuration: ciSmallServingDuration, DefaultCapacity: 1})
 
 	// Calculate test duration: enough time for 2 cycles
 	// Each cycle takes ~servingDuration to complete
@@ -96,7 +96,8 @@ func TestSmallLoadCI(t *testing.T) {
 		}
 
 		// Report status after creating orders
-		completed, incomplete, _ := orderService.GetOrderStats(ctx)
+		current, _ := orderService.GetOrderStats(ctx)
+		completed, incomplete := current.Completed, current.Incomplete
 		t.Logf("Cycle %d: Orders created. Queue size: %d, Completed: %d, Incomplete: %d",
 			cycle, orderService.GetQueueSize(), completed, incomplete)
 
@@ -112,7 +113,8 @@ func TestSmallLoadCI(t *testing.T) {
 	time.Sleep(ciSmallServingDuration + 5*time.Second)
 
 	// Final statistics
-	completed, incomplete, _ := orderService.GetOrderStats(ctx)
+	current, _ := orderService.GetOrderStats(ctx)
+	completed, incomplete := current.Completed, current.Incomplete
 
 	totalExpectedOrders := (numRegularCustomers + numVIPCustomers) * ciSmallNumCycles
 	completionRate := float64(completed) / float64(totalExpectedOrders) * 100
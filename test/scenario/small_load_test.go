@@ -61,8 +61,8 @@ func TestSmallLoad(t *testing.T) {
 
 	// Initialize services
 	orderQueue := queue.NewPriorityQueue()
-	orderService := service.NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, servingDuration)
-	cookService := service.NewCookService(userRepo, orderRepo, orderQueue, log, servingDuration)
+	orderService := service.NewOrderService(orderRepo, userRepo, foodRepo, orderQueue, log, service.OrderServiceConfig{ServingDuration: servingDuration})
+	cookService := service.NewCookService(userRepo, orderRepo, orderQueue, log, service.CookServiceConfig{ServingDuration: servingDuration, DefaultCapacity: 1})
 
 	// Start cook workers
 	for _, cook := range cooks {
@@ -89,7 +89,8 @@ func TestSmallLoad(t *testing.T) {
 				return
 			case <-ticker.C:
 				elapsed := time.Since(startTime)
-				completed, incomplete, _ := orderService.GetOrderStats(ctx)
+				current, _ := orderService.GetOrderStats(ctx)
+				completed, incomplete := current.Completed, current.Incomplete
 				statsLock.Lock()
 				stats[elapsed] = helpers.OrderStats{
 					Completed:  completed,
@@ -155,7 +156,8 @@ func TestSmallLoad(t *testing.T) {
 	time.Sleep(servingDuration + 2*time.Second)
 
 	// Final statistics
-	completed, incomplete, _ := orderService.GetOrderStats(ctx)
+	current, _ := orderService.GetOrderStats(ctx)
+	completed, incomplete := current.Completed, current.Incomplete
 
 	log.Info("=== Small Load Test Results ===")
 	log.Info("Regular Customers: %d", numRegularCustomers)